@@ -0,0 +1,145 @@
+// Command parsecheck runs the parser over a corpus of devotional posts and
+// reports field-level differences from the expected (golden) JSON stored next
+// to each post. With -update it rewrites the golden files instead.
+//
+// Usage:
+//
+//	go run ./cmd/parsecheck [-update] [-v] [dir ...]
+//
+// Each post is a .txt file; its golden file has the same name with a .json
+// extension. The default directories are the parser's test corpus.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+)
+
+var defaultDirs = []string{"parser/testdata/posts", "parser/testdata/templates"}
+
+func main() {
+	update := flag.Bool("update", false, "rewrite the golden files with the current parser output")
+	verbose := flag.Bool("v", false, "list posts without differences too")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: parsecheck [-update] [-v] [dir ...]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = defaultDirs
+	}
+
+	var posts []string
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(2)
+		}
+		posts = append(posts, files...)
+	}
+	if len(posts) == 0 {
+		fmt.Fprintf(os.Stderr, "❌ No posts found in %s\n", strings.Join(dirs, ", "))
+		os.Exit(2)
+	}
+
+	changed, diffs := 0, 0
+	for _, post := range posts {
+		n, err := check(post, *update, *verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", post, err)
+			os.Exit(2)
+		}
+		if n > 0 {
+			changed++
+			diffs += n
+		}
+	}
+
+	switch {
+	case *update:
+		fmt.Printf("📝 Updated %d of %d golden files\n", changed, len(posts))
+	case changed == 0:
+		fmt.Printf("✅ %d posts parsed as expected\n", len(posts))
+	default:
+		fmt.Printf("❌ %d of %d posts changed (%d fields)\n", changed, len(posts), diffs)
+		os.Exit(1)
+	}
+}
+
+// check parses a post and compares it with its golden file, or rewrites the
+// golden file when update is set. It returns the number of differing fields.
+func check(post string, update, verbose bool) (int, error) {
+	msg, err := os.ReadFile(post)
+	if err != nil {
+		return 0, err
+	}
+	got := parser.ParseDevotional(string(msg))
+
+	golden := strings.TrimSuffix(post, ".txt") + ".json"
+	var want models.Devotional
+	data, err := os.ReadFile(golden)
+	switch {
+	case os.IsNotExist(err) && update:
+	case os.IsNotExist(err):
+		fmt.Printf("⚠️  %s: no golden file, run with -update to create it\n", post)
+		return 1, nil
+	case err != nil:
+		return 0, err
+	default:
+		if err := json.Unmarshal(data, &want); err != nil {
+			return 0, fmt.Errorf("invalid golden file: %v", err)
+		}
+	}
+
+	diffs := parser.DiffDevotionals(want, got)
+	if update {
+		if data != nil && len(diffs) == 0 {
+			return 0, nil
+		}
+		out, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			return 0, err
+		}
+		if err := os.WriteFile(golden, append(out, '\n'), 0644); err != nil {
+			return 0, err
+		}
+		fmt.Printf("📝 %s\n", golden)
+		return 1, nil
+	}
+
+	if len(diffs) == 0 {
+		if verbose {
+			fmt.Printf("✅ %s\n", post)
+		}
+		return 0, nil
+	}
+
+	fmt.Printf("❌ %s\n", post)
+	for _, d := range diffs {
+		fmt.Printf("   %s:\n     - %s\n     + %s\n", d.Field, format(d.Want), format(d.Got))
+	}
+	return len(diffs), nil
+}
+
+// format renders a field value on one line, shortening long values
+func format(v interface{}) string {
+	if v == nil {
+		return "(absent)"
+	}
+	data, _ := json.Marshal(v)
+	s := string(data)
+	if len(s) > 120 {
+		s = s[:117] + "..."
+	}
+	return s
+}
@@ -13,18 +13,38 @@ import (
 	"lwnra-devo-api/facebook"
 	"lwnra-devo-api/handlers"
 	"lwnra-devo-api/middleware"
+	"lwnra-devo-api/parser"
 	"lwnra-devo-api/routes"
 	"lwnra-devo-api/scheduler"
+	"lwnra-devo-api/storage"
 )
 
 func main() {
+	// Token management subcommand
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		os.Exit(runTokenCommand(os.Args[2:]))
+	}
+
 	// Load configuration
 	cfg := config.Load()
 
+	// Initialize Facebook client with the primary token and its fallbacks
+	fbClient := facebook.NewWithCredentials(facebook.ParseCredentials(cfg.FacebookToken, cfg.FacebookTokens))
+
 	// Validate required configuration
-	if cfg.FacebookToken == "" {
+	if !fbClient.HasCredentials() {
 		log.Println("Warning: FB_ACCESS_TOKEN not set. Facebook sync will not work.")
 	}
+	if cfg.AdminToken == "" {
+		log.Println("Warning: ADMIN_TOKEN not set. Admin endpoints are disabled.")
+	}
+
+	// Load extra post templates
+	if cfg.TemplatesFile != "" {
+		if err := parser.LoadTemplates(cfg.TemplatesFile); err != nil {
+			log.Fatalf("Failed to load post templates: %v", err)
+		}
+	}
 
 	// Initialize database
 	db, err := database.New(cfg.DatabasePath)
@@ -33,14 +53,14 @@ func main() {
 	}
 	defer db.Close()
 
-	// Initialize Facebook client
-	fbClient := facebook.New(cfg.FacebookToken)
+	// Initialize image storage
+	images := storage.NewImageStore(cfg.ImageDir)
 
 	// Initialize scheduler
-	sched := scheduler.New(db, fbClient)
+	sched := scheduler.New(db, fbClient, images)
 	
 	// Start scheduler if Facebook token is available
-	if cfg.FacebookToken != "" {
+	if fbClient.HasCredentials() {
 		sched.Start()
 		defer sched.Stop()
 		
@@ -53,11 +73,13 @@ func main() {
 	}
 
 	// Initialize handlers
-	devotionalHandler := handlers.NewDevotionalHandler(db, fbClient)
-	systemHandler := handlers.NewSystemHandler(sched)
+	devotionalHandler := handlers.NewDevotionalHandler(db, fbClient, images)
+	systemHandler := handlers.NewSystemHandler(sched, fbClient)
+	statsHandler := handlers.NewStatsHandler(db)
+	adminHandler := handlers.NewAdminHandler(db, fbClient, images, cfg.AdminToken)
 
 	// Initialize router
-	router := routes.NewRouter(devotionalHandler, systemHandler)
+	router := routes.NewRouter(devotionalHandler, systemHandler, statsHandler, adminHandler)
 
 	// Apply middleware
 	handler := middleware.Logger(middleware.Recovery(middleware.CORS(router)))
@@ -86,7 +108,7 @@ func main() {
 	fmt.Println("\n🛑 Shutting down gracefully...")
 	
 	// Stop scheduler
-	if cfg.FacebookToken != "" {
+	if fbClient.HasCredentials() {
 		sched.Stop()
 	}
 	
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"lwnra-devo-api/facebook"
+)
+
+const tokenUsage = `Usage: lwnra-devo-api token <command> [flags]
+
+Manage the Facebook access token used for syncing.
+
+Commands:
+  setup     Run the full workflow: exchange, derive the page token, inspect and store it
+  exchange  Exchange a short-lived user token for a long-lived one
+  page      Derive the page access token from a user token
+  inspect   Show validity, type, scopes and expiry of a token (debug_token)
+  health    Inspect a token and test API access with it
+  store     Write a token to an env file
+
+Flags default to the FB_APP_ID, FB_APP_SECRET, FB_ACCESS_TOKEN and FB_PAGE_ID
+environment variables. Run "lwnra-devo-api token <command> -h" for details.
+`
+
+// tokenFlags holds the flags shared by the token subcommands
+type tokenFlags struct {
+	appID     string
+	appSecret string
+	token     string
+	pageID    string
+	envFile   string
+	envKey    string
+}
+
+// register adds the named shared flags to a flag set
+func (f *tokenFlags) register(fs *flag.FlagSet, names ...string) {
+	for _, name := range names {
+		switch name {
+		case "app-id":
+			fs.StringVar(&f.appID, "app-id", os.Getenv("FB_APP_ID"), "Facebook app ID")
+		case "app-secret":
+			fs.StringVar(&f.appSecret, "app-secret", os.Getenv("FB_APP_SECRET"), "Facebook app secret")
+		case "token":
+			fs.StringVar(&f.token, "token", os.Getenv("FB_ACCESS_TOKEN"), "access token to use")
+		case "page-id":
+			fs.StringVar(&f.pageID, "page-id", getEnvDefault("FB_PAGE_ID", facebook.DefaultPageID), "Facebook page ID")
+		case "env-file":
+			fs.StringVar(&f.envFile, "env-file", "", "env file to store the token in (prints an export line when empty)")
+			fs.StringVar(&f.envKey, "key", "FB_ACCESS_TOKEN", "variable name to store the token under")
+		}
+	}
+}
+
+// runTokenCommand runs the token subcommand and returns the process exit code
+func runTokenCommand(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Print(tokenUsage)
+		return 0
+	}
+
+	var f tokenFlags
+	fs := flag.NewFlagSet("token "+args[0], flag.ContinueOnError)
+
+	var run func() error
+	switch args[0] {
+	case "setup":
+		f.register(fs, "app-id", "app-secret", "token", "page-id", "env-file")
+		run = func() error { return tokenSetup(f) }
+	case "exchange":
+		f.register(fs, "app-id", "app-secret", "token")
+		run = func() error { return tokenExchange(f) }
+	case "page":
+		f.register(fs, "token", "page-id")
+		run = func() error { return tokenPage(f) }
+	case "inspect":
+		f.register(fs, "app-id", "app-secret", "token")
+		run = func() error { return tokenInspect(f) }
+	case "health":
+		f.register(fs, "app-id", "app-secret", "token")
+		run = func() error { return tokenHealth(f) }
+	case "store":
+		f.register(fs, "token", "env-file")
+		run = func() error { return storeToken(f.envFile, f.envKey, f.token) }
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown token command %q\n\n%s", args[0], tokenUsage)
+		return 2
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// tokenSetup exchanges a short-lived user token, derives the page token, inspects it and stores it
+func tokenSetup(f tokenFlags) error {
+	if err := requireFlags(map[string]string{"app-id": f.appID, "app-secret": f.appSecret, "token": f.token}); err != nil {
+		return err
+	}
+
+	tm := facebook.NewTokenManager(f.appID, f.appSecret, f.token)
+
+	fmt.Println("🔄 Step 1: Exchanging user token for a long-lived token...")
+	if _, err := tm.RefreshToken(); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔄 Step 2: Deriving the page token for page %s...\n", f.pageID)
+	pageToken, err := tm.GetPageToken(f.pageID)
+	if err != nil {
+		return err
+	}
+	if pageToken == "" {
+		return fmt.Errorf("no page token returned; make sure you are an admin of page %s and the token has pages_show_list", f.pageID)
+	}
+
+	fmt.Println("🔄 Step 3: Inspecting the page token...")
+	tm.SetCurrentToken(pageToken)
+	info, err := tm.GetTokenInfo()
+	if err != nil {
+		return err
+	}
+	printTokenInfo(info)
+
+	fmt.Println("🔄 Step 4: Storing the page token...")
+	return storeToken(f.envFile, f.envKey, pageToken)
+}
+
+// tokenExchange exchanges a short-lived user token for a long-lived one
+func tokenExchange(f tokenFlags) error {
+	if err := requireFlags(map[string]string{"app-id": f.appID, "app-secret": f.appSecret, "token": f.token}); err != nil {
+		return err
+	}
+
+	tm := facebook.NewTokenManager(f.appID, f.appSecret, f.token)
+	longLived, err := tm.RefreshToken()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(longLived)
+	return nil
+}
+
+// tokenPage derives the page access token from a user token
+func tokenPage(f tokenFlags) error {
+	if err := requireFlags(map[string]string{"token": f.token}); err != nil {
+		return err
+	}
+
+	tm := facebook.NewTokenManager("", "", f.token)
+	pageToken, err := tm.GetPageToken(f.pageID)
+	if err != nil {
+		return err
+	}
+	if pageToken == "" {
+		return fmt.Errorf("no page token returned for page %s", f.pageID)
+	}
+
+	fmt.Println(pageToken)
+	return nil
+}
+
+// tokenInspect prints the debug_token details of a token
+func tokenInspect(f tokenFlags) error {
+	if err := requireFlags(map[string]string{"token": f.token}); err != nil {
+		return err
+	}
+
+	tm := facebook.NewTokenManager(f.appID, f.appSecret, f.token)
+	info, err := tm.GetTokenInfo()
+	if err != nil {
+		return err
+	}
+
+	printTokenInfo(info)
+	return nil
+}
+
+// tokenHealth inspects a token and checks that it can call the API
+func tokenHealth(f tokenFlags) error {
+	if err := tokenInspect(f); err != nil {
+		return err
+	}
+
+	tm := facebook.NewTokenManager(f.appID, f.appSecret, f.token)
+
+	fmt.Println("🧪 Testing API access...")
+	valid, err := tm.ValidateToken()
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("API access failed; the token is invalid or revoked")
+	}
+	fmt.Println("   ✅ API Access: Working")
+
+	expiring, err := tm.IsTokenExpiringSoon()
+	if err != nil {
+		return err
+	}
+	if expiring {
+		fmt.Println("   ⚠️  WARNING: Token expires within 7 days! Run \"token setup\" to renew it.")
+	}
+	return nil
+}
+
+// printTokenInfo prints the fields of a debug_token response
+func printTokenInfo(info map[string]interface{}) {
+	fmt.Println("🔍 Token Details:")
+	fmt.Printf("   Valid: %v\n", info["is_valid"])
+	fmt.Printf("   Type: %v\n", info["type"])
+	fmt.Printf("   App ID: %v\n", info["app_id"])
+	if app, ok := info["application"]; ok {
+		fmt.Printf("   App: %v\n", app)
+	}
+
+	if scopes, ok := info["scopes"].([]interface{}); ok {
+		names := make([]string, 0, len(scopes))
+		for _, s := range scopes {
+			names = append(names, fmt.Sprint(s))
+		}
+		sort.Strings(names)
+		fmt.Printf("   Scopes: %s\n", strings.Join(names, ", "))
+	}
+
+	expiresAt, _ := info["expires_at"].(float64)
+	if expiresAt == 0 {
+		fmt.Println("   Expires: never (permanent)")
+		return
+	}
+	expires := time.Unix(int64(expiresAt), 0)
+	fmt.Printf("   Expires: %s (%d days left)\n", expires.Format(time.RFC1123), int(time.Until(expires).Hours()/24))
+}
+
+// storeToken writes key=token to an env file, replacing an existing entry,
+// or prints an export line when no file is given
+func storeToken(envFile, key, token string) error {
+	if token == "" {
+		return errors.New("no token to store")
+	}
+
+	if envFile == "" {
+		fmt.Println("💾 To use this token, set the environment variable:")
+		fmt.Printf("export %s=%q\n", key, token)
+		return nil
+	}
+
+	var lines []string
+	if data, err := os.ReadFile(envFile); err == nil {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", envFile, err)
+	}
+
+	entry := key + "=" + token
+	replaced := false
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimPrefix(l, "export "), key+"=") {
+			lines[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, entry)
+	}
+
+	if err := os.WriteFile(envFile, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %v", envFile, err)
+	}
+
+	fmt.Printf("💾 Stored %s in %s\n", key, envFile)
+	return nil
+}
+
+// requireFlags returns an error naming the first empty required flag
+func requireFlags(flags map[string]string) error {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if flags[name] == "" {
+			return fmt.Errorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// getEnvDefault gets an environment variable with a fallback default
+func getEnvDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
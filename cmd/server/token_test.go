@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *string
+		expected string
+	}{
+		{
+			name:     "replaces existing key",
+			existing: strPtr("FB_PAGE_TOKEN=old\n"),
+			expected: "FB_PAGE_TOKEN=new\n",
+		},
+		{
+			name:     "replaces exported key",
+			existing: strPtr("export FB_PAGE_TOKEN=old\n"),
+			expected: "FB_PAGE_TOKEN=new\n",
+		},
+		{
+			name:     "appends new key",
+			existing: strPtr("FB_APP_ID=123\n"),
+			expected: "FB_APP_ID=123\nFB_PAGE_TOKEN=new\n",
+		},
+		{
+			name:     "creates missing file",
+			expected: "FB_PAGE_TOKEN=new\n",
+		},
+		{
+			name:     "keeps unrelated lines and comments",
+			existing: strPtr("# Facebook\nFB_APP_ID=123\nFB_PAGE_TOKEN_OLD=keep\n\n# Server\nPORT=8080\nFB_PAGE_TOKEN=old\n"),
+			expected: "# Facebook\nFB_APP_ID=123\nFB_PAGE_TOKEN_OLD=keep\n\n# Server\nPORT=8080\nFB_PAGE_TOKEN=new\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envFile := filepath.Join(t.TempDir(), ".env")
+			if tt.existing != nil {
+				if err := os.WriteFile(envFile, []byte(*tt.existing), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := storeToken(envFile, "FB_PAGE_TOKEN", "new"); err != nil {
+				t.Fatalf("Failed to store token: %v", err)
+			}
+
+			data, err := os.ReadFile(envFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestStoreTokenRequiresToken(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := storeToken(envFile, "FB_PAGE_TOKEN", ""); err == nil {
+		t.Error("Expected an error for an empty token")
+	}
+	if _, err := os.Stat(envFile); !os.IsNotExist(err) {
+		t.Errorf("Expected no env file written, got %v", err)
+	}
+}
+
+func TestRequireFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"all set", map[string]string{"app-id": "1", "app-secret": "s"}, ""},
+		{"one empty", map[string]string{"app-id": "1", "app-secret": ""}, "-app-secret is required"},
+		{"first empty by name", map[string]string{"token": "", "app-id": ""}, "-app-id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requireFlags(tt.flags)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
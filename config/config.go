@@ -4,25 +4,36 @@ import "os"
 
 // Config holds application configuration
 type Config struct {
-	Port            string
-	DatabasePath    string
-	FacebookToken   string
-	Environment     string
+	Port           string
+	DatabasePath   string
+	ImageDir       string
+	FacebookToken  string
+	FacebookTokens string
+	AdminToken     string
+	TemplatesFile  string
+	Environment    string
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	// Use /app/data for database in production environments
 	defaultDBPath := "devotionals.db"
+	defaultImageDir := "images"
 	if getEnv("ENVIRONMENT", "development") == "production" {
 		defaultDBPath = "/app/data/devotionals.db"
+		defaultImageDir = "/app/data/images"
 	}
 
 	return &Config{
 		Port:          getEnv("PORT", "8082"),
 		DatabasePath:  getEnv("DB_PATH", defaultDBPath),
+		ImageDir:      getEnv("IMAGE_DIR", defaultImageDir),
 		FacebookToken: getEnv("FB_ACCESS_TOKEN", ""),
-		Environment:   getEnv("ENVIRONMENT", "development"),
+		// Ordered fallbacks, comma-separated, e.g. "system_user:EAA...,page:EAA...,user:EAA..."
+		FacebookTokens: getEnv("FB_ACCESS_TOKENS", ""),
+		AdminToken:     getEnv("ADMIN_TOKEN", ""),
+		TemplatesFile:  getEnv("TEMPLATES_FILE", ""), // extra post templates, added to or replacing the built-in ones
+		Environment:    getEnv("ENVIRONMENT", "development"),
 	}
 }
 
@@ -2,12 +2,14 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -59,7 +61,8 @@ func (db *DB) Close() error {
 
 // createTables creates the necessary database tables
 func (db *DB) createTables() error {
-	query := `CREATE TABLE IF NOT EXISTS devotionals (
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS devotionals (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		date TEXT,
 		reading TEXT,
@@ -71,17 +74,258 @@ func (db *DB) createTables() error {
 		body TEXT,
 		prayer TEXT,
 		UNIQUE(date, title)
-	)`
+	)`,
+		`CREATE TABLE IF NOT EXISTS devotional_images (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		devotional_id INTEGER NOT NULL REFERENCES devotionals(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		hash TEXT NOT NULL,
+		content_type TEXT,
+		source_url TEXT,
+		UNIQUE(devotional_id, position)
+	)`,
+		`CREATE TABLE IF NOT EXISTS engagement_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		devotional_id INTEGER NOT NULL REFERENCES devotionals(id) ON DELETE CASCADE,
+		reactions INTEGER NOT NULL,
+		comments INTEGER NOT NULL,
+		shares INTEGER NOT NULL,
+		captured_at TEXT NOT NULL
+	)`,
+		`CREATE INDEX IF NOT EXISTS idx_engagement_snapshots_devotional
+		ON engagement_snapshots(devotional_id, captured_at)`,
+		`CREATE TABLE IF NOT EXISTS devotional_overrides (
+		devotional_id INTEGER NOT NULL REFERENCES devotionals(id) ON DELETE CASCADE,
+		field TEXT NOT NULL,
+		parsed TEXT NOT NULL,
+		value TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		PRIMARY KEY(devotional_id, field)
+	)`,
+	}
 
-	_, err := db.conn.Exec(query)
-	return err
+	for _, query := range queries {
+		if _, err := db.conn.Exec(query); err != nil {
+			return err
+		}
+	}
+	return db.migrate()
+}
+
+// migrate adds columns introduced after the initial schema to existing databases
+func (db *DB) migrate() error {
+	columns := []struct {
+		table, name, definition string
+	}{
+		{"devotionals", "fb_post_id", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "posted_at", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "template", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "verses", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "citations", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "language", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "styles", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "footer", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "hashtags", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "urls", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "mentions", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "series", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "body_blocks", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "memory_verse", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "pull_quote", "TEXT NOT NULL DEFAULT ''"},
+		{"devotionals", "status", "TEXT NOT NULL DEFAULT 'published'"},
+		{"devotionals", "review_reasons", "TEXT NOT NULL DEFAULT ''"},
+	}
+
+	quarantine := false
+	for _, c := range columns {
+		exists, err := db.hasColumn(c.table, c.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.name, c.definition)
+		if _, err := db.conn.Exec(query); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %v", c.table, c.name, err)
+		}
+		// Devotionals stored before the quality gate are checked against it
+		quarantine = quarantine || c.name == "status"
+	}
+
+	if quarantine {
+		if err := db.quarantineIncomplete(); err != nil {
+			return fmt.Errorf("failed to quarantine incomplete devotionals: %v", err)
+		}
+	}
+	return nil
+}
+
+// hasColumn reports whether a table already has the given column
+func (db *DB) hasColumn(table, column string) (bool, error) {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// devotionalColumns lists the devotional columns read by scanDevotional, in scan order
+const devotionalColumns = `date, reading, version, passage, refqs, title, author, body, prayer, fb_post_id, posted_at, template, verses, citations, language, styles, footer, hashtags, urls, mentions, series, body_blocks, memory_verse, pull_quote, id, status, review_reasons`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// scanDevotional scans a row selected with devotionalColumns into a devotional
+func scanDevotional(row rowScanner) (models.Devotional, error) {
+	var devo models.Devotional
+	var refqs, verses, citations, styles, hashtags, urls, mentions, series, bodyBlocks, memoryVerse, reviewReasons string
+
+	err := row.Scan(
+		&devo.Date,
+		&devo.Reading,
+		&devo.Version,
+		&devo.Passage,
+		&refqs,
+		&devo.Title,
+		&devo.Author,
+		&devo.Body,
+		&devo.Prayer,
+		&devo.FBPostID,
+		&devo.PostedAt,
+		&devo.Template,
+		&verses,
+		&citations,
+		&devo.Language,
+		&styles,
+		&devo.Footer,
+		&hashtags,
+		&urls,
+		&mentions,
+		&series,
+		&bodyBlocks,
+		&memoryVerse,
+		&devo.PullQuote,
+		&devo.ID,
+		&devo.Status,
+		&reviewReasons,
+	)
+	if err != nil {
+		return devo, err
+	}
+
+	if err := decodeList(verses, &devo.Verses); err != nil {
+		return devo, fmt.Errorf("failed to decode verses: %v", err)
+	}
+	if err := decodeList(citations, &devo.Citations); err != nil {
+		return devo, fmt.Errorf("failed to decode citations: %v", err)
+	}
+	if err := decodeList(styles, &devo.Styles); err != nil {
+		return devo, fmt.Errorf("failed to decode styles: %v", err)
+	}
+	if err := decodeList(hashtags, &devo.Hashtags); err != nil {
+		return devo, fmt.Errorf("failed to decode hashtags: %v", err)
+	}
+	if err := decodeList(urls, &devo.URLs); err != nil {
+		return devo, fmt.Errorf("failed to decode urls: %v", err)
+	}
+	if err := decodeList(mentions, &devo.Mentions); err != nil {
+		return devo, fmt.Errorf("failed to decode mentions: %v", err)
+	}
+	if err := decodeList(series, &devo.Series); err != nil {
+		return devo, fmt.Errorf("failed to decode series: %v", err)
+	}
+	if err := decodeList(bodyBlocks, &devo.BodyBlocks); err != nil {
+		return devo, fmt.Errorf("failed to decode body blocks: %v", err)
+	}
+	if err := decodeList(memoryVerse, &devo.MemoryVerse); err != nil {
+		return devo, fmt.Errorf("failed to decode memory verse: %v", err)
+	}
+	if err := decodeList(reviewReasons, &devo.ReviewReasons); err != nil {
+		return devo, fmt.Errorf("failed to decode review reasons: %v", err)
+	}
+	// Version metadata comes from the catalogue rather than the database
+	devo.VersionInfo, _ = scripture.LookupVersion(devo.Version)
+
+	// Convert refqs back to slice
+	if refqs != "" {
+		devo.ReflectionQs = strings.Split(refqs, "\n")
+	}
+
+	return devo, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in a search string
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// encodeList encodes a slice as JSON for storage, or "" when it is empty
+func encodeList(list interface{}) string {
+	data, _ := json.Marshal(list)
+	if s := string(data); s != "null" && s != "[]" {
+		return s
+	}
+	return ""
+}
+
+// decodeList decodes a slice stored with encodeList
+func decodeList(data string, list interface{}) error {
+	if data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), list)
 }
 
-// SaveDevotional saves a devotional to the database
-func (db *DB) SaveDevotional(devo models.Devotional) error {
-	query := `INSERT OR IGNORE INTO devotionals
-		(date, reading, version, passage, refqs, title, author, body, prayer)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+// SaveDevotional saves a devotional to the database, held for review when it
+// is incomplete, and sets its status and review reasons. Existing devotionals
+// are left untouched, except that a missing Facebook post reference is
+// filled in.
+func (db *DB) SaveDevotional(devo *models.Devotional) error {
+	devo.ApplyQualityGate()
+
+	// The post may be stored under another date or title, e.g. after an
+	// admin corrected them
+	if devo.FBPostID != "" {
+		var exists bool
+		if err := db.conn.QueryRow(`SELECT EXISTS(SELECT 1 FROM devotionals WHERE fb_post_id = ?)`, devo.FBPostID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	query := `INSERT INTO devotionals
+		(date, reading, version, passage, refqs, title, author, body, prayer, fb_post_id, posted_at, template, verses, citations, language, styles, footer, hashtags, urls, mentions, series, body_blocks, memory_verse, pull_quote, status, review_reasons)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(date, title) DO UPDATE SET
+			fb_post_id = COALESCE(NULLIF(devotionals.fb_post_id, ''), excluded.fb_post_id),
+			posted_at = COALESCE(NULLIF(devotionals.posted_at, ''), excluded.posted_at)`
 
 	stmt, err := db.conn.Prepare(query)
 	if err != nil {
@@ -99,19 +343,122 @@ func (db *DB) SaveDevotional(devo models.Devotional) error {
 		devo.Author,
 		devo.Body,
 		devo.Prayer,
+		devo.FBPostID,
+		devo.PostedAt,
+		devo.Template,
+		encodeList(devo.Verses),
+		encodeList(devo.Citations),
+		devo.Language,
+		encodeList(devo.Styles),
+		devo.Footer,
+		encodeList(devo.Hashtags),
+		encodeList(devo.URLs),
+		encodeList(devo.Mentions),
+		encodeList(devo.Series),
+		encodeList(devo.BodyBlocks),
+		encodeList(devo.MemoryVerse),
+		devo.PullQuote,
+		devo.Status,
+		encodeList(devo.ReviewReasons),
 	)
 
 	return err
 }
 
-// GetDevotionals retrieves a limited number of devotionals from the database
-func (db *DB) GetDevotionals(limit int) ([]models.Devotional, error) {
-	query := `SELECT date, reading, version, passage, refqs, title, author, body, prayer 
-			  FROM devotionals 
-			  ORDER BY date DESC 
+// UpsertDevotional stores a devotional, replacing the stored version of the same
+// Facebook post (even if its date or title changed) or of the same date and title.
+// It is published when complete and held for review otherwise, and its status
+// and review reasons are set.
+func (db *DB) UpsertDevotional(devo *models.Devotional) error {
+	devo.ApplyQualityGate()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	refqs := strings.Join(devo.ReflectionQs, "\n")
+	verses := encodeList(devo.Verses)
+	citations := encodeList(devo.Citations)
+	styles := encodeList(devo.Styles)
+	hashtags, urls, mentions := encodeList(devo.Hashtags), encodeList(devo.URLs), encodeList(devo.Mentions)
+	series, bodyBlocks := encodeList(devo.Series), encodeList(devo.BodyBlocks)
+	memoryVerse := encodeList(devo.MemoryVerse)
+	reviewReasons := encodeList(devo.ReviewReasons)
+
+	if devo.FBPostID != "" {
+		res, err := tx.Exec(`UPDATE devotionals SET
+			date = ?, reading = ?, version = ?, passage = ?, refqs = ?,
+			title = ?, author = ?, body = ?, prayer = ?, posted_at = ?, template = ?, verses = ?, citations = ?, language = ?, styles = ?,
+			footer = ?, hashtags = ?, urls = ?, mentions = ?, series = ?, body_blocks = ?, memory_verse = ?, pull_quote = ?, status = ?, review_reasons = ?
+			WHERE fb_post_id = ?`,
+			devo.Date, devo.Reading, devo.Version, devo.Passage, refqs,
+			devo.Title, devo.Author, devo.Body, devo.Prayer, devo.PostedAt, devo.Template, verses, citations, devo.Language, styles,
+			devo.Footer, hashtags, urls, mentions, series, bodyBlocks, memoryVerse, devo.PullQuote, devo.Status, reviewReasons,
+			devo.FBPostID,
+		)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			return tx.Commit()
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO devotionals
+		(date, reading, version, passage, refqs, title, author, body, prayer, fb_post_id, posted_at, template, verses, citations, language, styles, footer, hashtags, urls, mentions, series, body_blocks, memory_verse, pull_quote, status, review_reasons)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(date, title) DO UPDATE SET
+			reading = excluded.reading,
+			version = excluded.version,
+			passage = excluded.passage,
+			refqs = excluded.refqs,
+			author = excluded.author,
+			body = excluded.body,
+			prayer = excluded.prayer,
+			fb_post_id = excluded.fb_post_id,
+			posted_at = excluded.posted_at,
+			template = excluded.template,
+			verses = excluded.verses,
+			citations = excluded.citations,
+			language = excluded.language,
+			styles = excluded.styles,
+			footer = excluded.footer,
+			hashtags = excluded.hashtags,
+			urls = excluded.urls,
+			mentions = excluded.mentions,
+			series = excluded.series,
+			body_blocks = excluded.body_blocks,
+			memory_verse = excluded.memory_verse,
+			pull_quote = excluded.pull_quote,
+			status = excluded.status,
+			review_reasons = excluded.review_reasons`,
+		devo.Date, devo.Reading, devo.Version, devo.Passage, refqs,
+		devo.Title, devo.Author, devo.Body, devo.Prayer, devo.FBPostID, devo.PostedAt, devo.Template, verses, citations, devo.Language, styles,
+		devo.Footer, hashtags, urls, mentions, series, bodyBlocks, memoryVerse, devo.PullQuote, devo.Status, reviewReasons,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDevotionals retrieves a limited number of published devotionals from
+// the database, only those in the given language and containing the search
+// text, unless they are empty. The search ignores ASCII case.
+func (db *DB) GetDevotionals(limit int, language, search string) ([]models.Devotional, error) {
+	query := `SELECT ` + devotionalColumns + `
+			  FROM devotionals
+			  WHERE status = 'published' AND (? = '' OR language = ?)
+			    AND (? = '' OR title LIKE ? ESCAPE '\' OR body LIKE ? ESCAPE '\' OR reading LIKE ? ESCAPE '\'
+			         OR author LIKE ? ESCAPE '\' OR prayer LIKE ? ESCAPE '\')
+			  ORDER BY date DESC
 			  LIMIT ?`
-	
-	rows, err := db.conn.Query(query, limit)
+
+	pattern := "%" + likeEscaper.Replace(search) + "%"
+	rows, err := db.conn.Query(query, language, language, search, pattern, pattern, pattern, pattern, pattern, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -119,65 +466,87 @@ func (db *DB) GetDevotionals(limit int) ([]models.Devotional, error) {
 
 	var devotionals []models.Devotional
 	for rows.Next() {
-		var devo models.Devotional
-		var refqs string
-		
-		err := rows.Scan(
-			&devo.Date,
-			&devo.Reading,
-			&devo.Version,
-			&devo.Passage,
-			&refqs,
-			&devo.Title,
-			&devo.Author,
-			&devo.Body,
-			&devo.Prayer,
-		)
+		devo, err := scanDevotional(rows)
 		if err != nil {
 			return nil, err
 		}
-		
-		// Convert refqs back to slice
-		if refqs != "" {
-			devo.ReflectionQs = strings.Split(refqs, "\n")
-		}
-		
 		devotionals = append(devotionals, devo)
 	}
-	
+
 	return devotionals, nil
 }
 
-// GetDevotionalByDate retrieves a devotional by date
+// GetDevotionalByDate retrieves the published devotional of a date
 func (db *DB) GetDevotionalByDate(date string) (*models.Devotional, error) {
-	query := `SELECT date, reading, version, passage, refqs, title, author, body, prayer 
-			  FROM devotionals 
-			  WHERE date = ? 
+	query := `SELECT ` + devotionalColumns + `
+			  FROM devotionals
+			  WHERE date = ? AND status = 'published'
 			  LIMIT 1`
-	
-	var devo models.Devotional
-	var refqs string
-	
+
+	devo, err := scanDevotional(db.conn.QueryRow(query, date))
+	if err != nil {
+		return nil, err
+	}
+
+	return &devo, nil
+}
+
+// SaveDevotionalImages links stored images to the devotional identified by date and title,
+// replacing the images previously linked to it
+func (db *DB) SaveDevotionalImages(date, title string, images []models.DevotionalImage) error {
+	var devotionalID int64
+	err := db.conn.QueryRow(`SELECT id FROM devotionals WHERE date = ? AND title = ?`, date, title).Scan(&devotionalID)
+	if err != nil {
+		return fmt.Errorf("devotional not found: %v", err)
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// A resync may find fewer images than before
+	if _, err := tx.Exec(`DELETE FROM devotional_images WHERE devotional_id = ?`, devotionalID); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO devotional_images
+		(devotional_id, position, hash, content_type, source_url)
+		VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, img := range images {
+		if _, err := stmt.Exec(devotionalID, img.Position, img.Hash, img.ContentType, img.SourceURL); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetDevotionalImage retrieves the main image of the devotional for a date
+func (db *DB) GetDevotionalImage(date string) (*models.DevotionalImage, error) {
+	query := `SELECT i.position, i.hash, i.content_type, i.source_url
+			  FROM devotional_images i
+			  JOIN devotionals d ON d.id = i.devotional_id
+			  WHERE d.date = ? AND d.status = 'published'
+			  ORDER BY i.position
+			  LIMIT 1`
+
+	var img models.DevotionalImage
 	err := db.conn.QueryRow(query, date).Scan(
-		&devo.Date,
-		&devo.Reading,
-		&devo.Version,
-		&devo.Passage,
-		&refqs,
-		&devo.Title,
-		&devo.Author,
-		&devo.Body,
-		&devo.Prayer,
+		&img.Position,
+		&img.Hash,
+		&img.ContentType,
+		&img.SourceURL,
 	)
-	
 	if err != nil {
 		return nil, err
 	}
-	
-	// Convert refqs back to slice
-	if refqs != "" {
-		devo.ReflectionQs = strings.Split(refqs, "\n")
-	}
-	
-	return &devo, nil
+
+	return &img, nil
 }
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"bytes"
+	"database/sql"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+func TestMigrateHoldsIncompleteDevotionals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	// A database from before the quality gate, with a devotional that lost
+	// its title
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = conn.Exec(`CREATE TABLE devotionals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date TEXT, reading TEXT, version TEXT, passage TEXT, refqs TEXT,
+		title TEXT, author TEXT, body TEXT, prayer TEXT,
+		UNIQUE(date, title)
+	);
+	INSERT INTO devotionals (date, reading, version, passage, refqs, title, author, body, prayer) VALUES
+		('August 9, 2025', 'Psalm 71:1-3', 'NIV', '1 In you, LORD, I have taken refuge.', '[]', 'A REFUGE IN EVERY SEASON', 'Grace Dela Cruz', 'God is our refuge.', 'Amen.'),
+		('August 10, 2025', 'Psalm 46:1-3', 'NIV', '1 God is our refuge and strength.', '[]', '', 'Grace Dela Cruz', 'God is our help.', 'Amen.')`)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("Failed to seed database: %v", err)
+	}
+
+	var logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logged)
+
+	db, err := New(path)
+	if err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	defer db.Close()
+
+	pending, err := db.GetPendingDevotionals()
+	if err != nil {
+		t.Fatalf("Failed to get pending devotionals: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != 2 || pending[0].Status != models.StatusPendingReview {
+		t.Fatalf("Expected the devotional without a title held for review, got %+v", pending)
+	}
+	if published, err := db.GetDevotionalByDate("August 9, 2025"); err != nil || published.Status != models.StatusPublished {
+		t.Errorf("Expected the complete devotional published, got %+v, %v", published, err)
+	}
+	if !strings.Contains(logged.String(), "Held 1 devotionals") || !strings.Contains(logged.String(), "2 (missing title)") {
+		t.Errorf("Expected the held devotional logged, got %q", logged.String())
+	}
+}
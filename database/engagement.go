@@ -0,0 +1,129 @@
+package database
+
+import (
+	"time"
+
+	"lwnra-devo-api/models"
+)
+
+// PostRef links a stored devotional to its Facebook post
+type PostRef struct {
+	DevotionalID int64
+	PostID       string
+}
+
+// GetPostsSince returns the Facebook posts of devotionals posted at or after since
+func (db *DB) GetPostsSince(since time.Time) ([]PostRef, error) {
+	query := `SELECT id, fb_post_id
+			  FROM devotionals
+			  WHERE fb_post_id != '' AND posted_at >= ?
+			  ORDER BY posted_at DESC`
+
+	rows, err := db.conn.Query(query, since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []PostRef
+	for rows.Next() {
+		var ref PostRef
+		if err := rows.Scan(&ref.DevotionalID, &ref.PostID); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
+
+// SaveEngagementSnapshot records the engagement of a devotional's post at a point in time
+func (db *DB) SaveEngagementSnapshot(devotionalID int64, engagement models.Engagement, capturedAt time.Time) error {
+	query := `INSERT INTO engagement_snapshots
+		(devotional_id, reactions, comments, shares, captured_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err := db.conn.Exec(query,
+		devotionalID,
+		engagement.Reactions,
+		engagement.Comments,
+		engagement.Shares,
+		capturedAt.UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetTopEngagement returns the devotionals posted at or after since, ranked by
+// the total engagement of their latest snapshot
+func (db *DB) GetTopEngagement(since time.Time, limit int) ([]models.DevotionalEngagement, error) {
+	query := `SELECT d.date, d.title, d.reading, d.fb_post_id, s.reactions, s.comments, s.shares, s.captured_at
+			  FROM devotionals d
+			  JOIN engagement_snapshots s ON s.id = (
+				  SELECT id FROM engagement_snapshots
+				  WHERE devotional_id = d.id
+				  ORDER BY captured_at DESC, id DESC
+				  LIMIT 1
+			  )
+			  WHERE d.posted_at >= ? AND d.status = 'published'
+			  ORDER BY s.reactions + s.comments + s.shares DESC, d.posted_at DESC
+			  LIMIT ?`
+
+	rows, err := db.conn.Query(query, since.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []models.DevotionalEngagement{}
+	for rows.Next() {
+		var e models.DevotionalEngagement
+		var capturedAt string
+		err := rows.Scan(
+			&e.Date,
+			&e.Title,
+			&e.Reading,
+			&e.FBPostID,
+			&e.Reactions,
+			&e.Comments,
+			&e.Shares,
+			&capturedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		e.Total = e.Engagement.Total()
+		e.CapturedAt, _ = time.Parse(time.RFC3339, capturedAt)
+		results = append(results, e)
+	}
+
+	return results, rows.Err()
+}
+
+// GetEngagementHistory returns all engagement snapshots of the published
+// devotional for a date, oldest first
+func (db *DB) GetEngagementHistory(date string) ([]models.EngagementSnapshot, error) {
+	query := `SELECT s.reactions, s.comments, s.shares, s.captured_at
+			  FROM engagement_snapshots s
+			  JOIN devotionals d ON d.id = s.devotional_id
+			  WHERE d.date = ? AND d.status = 'published'
+			  ORDER BY s.captured_at, s.id`
+
+	rows, err := db.conn.Query(query, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.EngagementSnapshot{}
+	for rows.Next() {
+		var snap models.EngagementSnapshot
+		var capturedAt string
+		if err := rows.Scan(&snap.Reactions, &snap.Comments, &snap.Shares, &capturedAt); err != nil {
+			return nil, err
+		}
+		snap.CapturedAt, _ = time.Parse(time.RFC3339, capturedAt)
+		history = append(history, snap)
+	}
+
+	return history, rows.Err()
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"lwnra-devo-api/models"
+)
+
+// GetOverrides returns the field overrides of a devotional, in field order
+func (db *DB) GetOverrides(devotionalID int64) ([]models.FieldOverride, error) {
+	rows, err := db.conn.Query(`SELECT field, parsed, value, updated_at
+			  FROM devotional_overrides
+			  WHERE devotional_id = ?
+			  ORDER BY field`, devotionalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := []models.FieldOverride{}
+	for rows.Next() {
+		var o models.FieldOverride
+		var parsed, value string
+		if err := rows.Scan(&o.Field, &parsed, &value, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		o.Parsed, o.Override = []byte(parsed), []byte(value)
+		overrides = append(overrides, o)
+	}
+	return overrides, rows.Err()
+}
+
+// SaveOverride stores a field override of a devotional, replacing the one of
+// the same field. UpdatedAt is set when empty.
+func (db *DB) SaveOverride(devotionalID int64, o models.FieldOverride) error {
+	return saveOverride(db.conn, devotionalID, o)
+}
+
+func saveOverride(ex execer, devotionalID int64, o models.FieldOverride) error {
+	if o.UpdatedAt == "" {
+		o.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	_, err := ex.Exec(`INSERT INTO devotional_overrides (devotional_id, field, parsed, value, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(devotional_id, field) DO UPDATE SET
+			parsed = excluded.parsed,
+			value = excluded.value,
+			updated_at = excluded.updated_at`,
+		devotionalID, o.Field, string(o.Parsed), string(o.Override), o.UpdatedAt)
+	return err
+}
+
+// UpdateDevotionalOverrides stores an admin's corrections of a devotional in
+// one transaction: the corrected devotional, the overrides of the fields set
+// and the removal of the overrides of the fields cleared, so the stored
+// fields and overrides always agree
+func (db *DB) UpdateDevotionalOverrides(devo models.Devotional, set []models.FieldOverride, cleared []string) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateDevotional(tx, devo); err != nil {
+		return err
+	}
+	for _, o := range set {
+		if err := saveOverride(tx, devo.ID, o); err != nil {
+			return fmt.Errorf("failed to save override of %s: %v", o.Field, err)
+		}
+	}
+	for _, field := range cleared {
+		if _, err := tx.Exec(`DELETE FROM devotional_overrides WHERE devotional_id = ? AND field = ?`, devo.ID, field); err != nil {
+			return fmt.Errorf("failed to remove override of %s: %v", field, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// FindDevotionalID returns the ID of the stored devotional of a Facebook post,
+// or of the same date and title, and 0 when there is none
+func (db *DB) FindDevotionalID(fbPostID, date, title string) (int64, error) {
+	var id int64
+	err := db.conn.QueryRow(`SELECT id FROM devotionals
+			  WHERE (? != '' AND fb_post_id = ?) OR (date = ? AND title = ?)
+			  ORDER BY fb_post_id = ? DESC
+			  LIMIT 1`, fbPostID, fbPostID, date, title, fbPostID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
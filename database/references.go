@@ -0,0 +1,65 @@
+package database
+
+import (
+	"sort"
+
+	"lwnra-devo-api/models"
+)
+
+// GetCrossReferences indexes the passages the devotionals cite outside of
+// their own readings, most cited first. When book is not empty only citations
+// of that book are returned.
+func (db *DB) GetCrossReferences(book string) ([]models.CrossReference, error) {
+	rows, err := db.conn.Query(`SELECT date, title, citations
+			  FROM devotionals
+			  WHERE citations != '' AND status = 'published'
+			  ORDER BY posted_at DESC, date DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := make(map[string]*models.CrossReference)
+	for rows.Next() {
+		var date, title, data string
+		if err := rows.Scan(&date, &title, &data); err != nil {
+			return nil, err
+		}
+
+		var citations []models.Citation
+		if err := decodeList(data, &citations); err != nil {
+			return nil, err
+		}
+
+		cited := make(map[string]bool)
+		for _, c := range citations {
+			if c.InReading || cited[c.Reference] || (book != "" && c.Book != book) {
+				continue
+			}
+			cited[c.Reference] = true
+
+			ref, ok := index[c.Reference]
+			if !ok {
+				ref = &models.CrossReference{Reference: c.Reference, Book: c.Book}
+				index[c.Reference] = ref
+			}
+			ref.Count++
+			ref.Devotionals = append(ref.Devotionals, models.CitingSummary{Date: date, Title: title})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.CrossReference, 0, len(index))
+	for _, ref := range index {
+		results = append(results, *ref)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Reference < results[j].Reference
+	})
+	return results, nil
+}
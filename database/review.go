@@ -0,0 +1,127 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"lwnra-devo-api/models"
+)
+
+// quarantineIncomplete holds back the devotionals stored before the quality
+// gate that fail it, logging which ones so they can be found in the review
+// queue
+func (db *DB) quarantineIncomplete() error {
+	rows, err := db.conn.Query(`SELECT id, COALESCE(title, ''), COALESCE(reading, ''), COALESCE(body, '')
+			  FROM devotionals
+			  WHERE COALESCE(title, '') = '' OR COALESCE(reading, '') = '' OR COALESCE(body, '') = ''`)
+	if err != nil {
+		return err
+	}
+	var incomplete []models.Devotional
+	for rows.Next() {
+		var devo models.Devotional
+		if err := rows.Scan(&devo.ID, &devo.Title, &devo.Reading, &devo.Body); err != nil {
+			rows.Close()
+			return err
+		}
+		incomplete = append(incomplete, devo)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var held []string
+	for _, devo := range incomplete {
+		devo.ApplyQualityGate()
+		_, err := db.conn.Exec(`UPDATE devotionals SET status = ?, review_reasons = ? WHERE id = ?`,
+			devo.Status, encodeList(devo.ReviewReasons), devo.ID)
+		if err != nil {
+			return err
+		}
+		if devo.Status == models.StatusPendingReview {
+			held = append(held, fmt.Sprintf("%d (%s)", devo.ID, strings.Join(devo.ReviewReasons, ", ")))
+		}
+	}
+	if len(held) > 0 {
+		log.Printf("Held %d devotionals stored before the quality gate for review: %s", len(held), strings.Join(held, "; "))
+	}
+	return nil
+}
+
+// GetPendingDevotionals returns the devotionals held for review, oldest first
+func (db *DB) GetPendingDevotionals() ([]models.Devotional, error) {
+	rows, err := db.conn.Query(`SELECT `+devotionalColumns+`
+			  FROM devotionals
+			  WHERE status = ?
+			  ORDER BY posted_at, id`, models.StatusPendingReview)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devotionals := []models.Devotional{}
+	for rows.Next() {
+		devo, err := scanDevotional(rows)
+		if err != nil {
+			return nil, err
+		}
+		devotionals = append(devotionals, devo)
+	}
+	return devotionals, rows.Err()
+}
+
+// GetDevotionalByID retrieves a devotional by its ID, published or not
+func (db *DB) GetDevotionalByID(id int64) (*models.Devotional, error) {
+	devo, err := scanDevotional(db.conn.QueryRow(`SELECT `+devotionalColumns+` FROM devotionals WHERE id = ?`, id))
+	if err != nil {
+		return nil, fmt.Errorf("devotional not found: %v", err)
+	}
+	return &devo, nil
+}
+
+// UpdateDevotional overwrites the fields of the devotional with the same ID.
+// Its review reasons are checked again, but its status is kept: a devotional
+// held for review stays held until it is approved.
+func (db *DB) UpdateDevotional(devo models.Devotional) error {
+	return updateDevotional(db.conn, devo)
+}
+
+func updateDevotional(ex execer, devo models.Devotional) error {
+	status := devo.Status
+	devo.ApplyQualityGate()
+	if status != "" {
+		devo.Status = status
+	}
+
+	res, err := ex.Exec(`UPDATE devotionals SET
+			date = ?, reading = ?, version = ?, passage = ?, refqs = ?,
+			title = ?, author = ?, body = ?, prayer = ?, template = ?, verses = ?, citations = ?, language = ?, styles = ?,
+			footer = ?, hashtags = ?, urls = ?, mentions = ?, series = ?, body_blocks = ?, memory_verse = ?, pull_quote = ?, status = ?, review_reasons = ?
+			WHERE id = ?`,
+		devo.Date, devo.Reading, devo.Version, devo.Passage, strings.Join(devo.ReflectionQs, "\n"),
+		devo.Title, devo.Author, devo.Body, devo.Prayer, devo.Template, encodeList(devo.Verses), encodeList(devo.Citations), devo.Language, encodeList(devo.Styles),
+		devo.Footer, encodeList(devo.Hashtags), encodeList(devo.URLs), encodeList(devo.Mentions), encodeList(devo.Series), encodeList(devo.BodyBlocks), encodeList(devo.MemoryVerse), devo.PullQuote, devo.Status, encodeList(devo.ReviewReasons),
+		devo.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("devotional not found: %d", devo.ID)
+	}
+	return nil
+}
+
+// SetDevotionalStatus publishes a devotional or holds it for review
+func (db *DB) SetDevotionalStatus(id int64, status string) error {
+	res, err := db.conn.Exec(`UPDATE devotionals SET status = ? WHERE id = ?`, status, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("devotional not found: %d", id)
+	}
+	return nil
+}
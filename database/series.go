@@ -0,0 +1,196 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+// Kinds of series
+const (
+	SeriesMarked = "marked" // named in the posts, e.g. "FRUIT OF THE SPIRIT (PART 2 OF 5)"
+	SeriesBook   = "book"   // consecutive readings through one book
+)
+
+const (
+	// displayDateLayout is the format devotional dates are stored in
+	displayDateLayout = "January 2, 2006"
+
+	// minBookRun is the fewest consecutive devotionals reading through a book
+	// that make a series
+	minBookRun = 3
+	// maxBookRunGap is the most days between two devotionals of a book run
+	maxBookRunGap = 7
+)
+
+// seriesDevotional is a devotional as grouped into series
+type seriesDevotional struct {
+	entry  models.SeriesEntry
+	time   time.Time // zero when the date can't be parsed
+	marker *models.SeriesMarker
+}
+
+// GetSeries groups the devotionals into series, most recent first. The
+// entries are left out, GetSeriesByID returns them.
+func (db *DB) GetSeries() ([]models.Series, error) {
+	series, err := db.groupSeries()
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		series[i].Entries = nil
+	}
+	return series, nil
+}
+
+// GetSeriesByID returns a series with its entries in series order
+func (db *DB) GetSeriesByID(id string) (*models.Series, error) {
+	series, err := db.groupSeries()
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		if series[i].ID == id {
+			return &series[i], nil
+		}
+	}
+	return nil, fmt.Errorf("series not found: %s", id)
+}
+
+// groupSeries groups devotionals sharing a series name, then runs of
+// consecutive devotionals reading through the same book
+func (db *DB) groupSeries() ([]models.Series, error) {
+	rows, err := db.conn.Query(`SELECT date, title, reading, series FROM devotionals WHERE status = 'published'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devos []seriesDevotional
+	for rows.Next() {
+		var d seriesDevotional
+		var marker string
+		if err := rows.Scan(&d.entry.Date, &d.entry.Title, &d.entry.Reading, &marker); err != nil {
+			return nil, err
+		}
+		if err := decodeList(marker, &d.marker); err != nil {
+			return nil, fmt.Errorf("failed to decode series: %v", err)
+		}
+		if d.marker != nil {
+			d.entry.Part = d.marker.Part
+		}
+		d.time, _ = time.Parse(displayDateLayout, d.entry.Date)
+		devos = append(devos, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(devos, func(i, j int) bool { return devos[i].time.Before(devos[j].time) })
+
+	var series []models.Series
+
+	// Series named in the posts
+	named := make(map[string][]seriesDevotional)
+	var names []string
+	var rest []seriesDevotional
+	for _, d := range devos {
+		if d.marker == nil || seriesSlug(d.marker.Title) == "" {
+			rest = append(rest, d)
+			continue
+		}
+		id := seriesSlug(d.marker.Title)
+		if _, ok := named[id]; !ok {
+			names = append(names, id)
+		}
+		named[id] = append(named[id], d)
+	}
+	for _, id := range names {
+		members := named[id]
+		sort.SliceStable(members, func(i, j int) bool {
+			a, b := members[i].entry.Part, members[j].entry.Part
+			return a != 0 && (b == 0 || a < b)
+		})
+		s := newSeries(id, members[0].marker.Title, SeriesMarked, members)
+		for _, m := range members {
+			if m.marker.Of > s.Parts {
+				s.Parts = m.marker.Of
+			}
+		}
+		series = append(series, s)
+	}
+
+	// Runs through one book
+	var run []seriesDevotional
+	var runBook string
+	var last scripture.Range
+	flush := func() {
+		if len(run) >= minBookRun {
+			id := seriesSlug(runBook) + "-" + run[0].time.Format("2006-01-02")
+			s := newSeries(id, runBook, SeriesBook, run)
+			s.Book = runBook
+			series = append(series, s)
+		}
+		run = nil
+	}
+	for _, d := range rest {
+		ref, err := scripture.ParseOne(d.entry.Reading)
+		if err != nil || d.time.IsZero() {
+			flush()
+			continue
+		}
+		first := ref.Ranges[0]
+		if len(run) > 0 {
+			gap := d.time.Sub(run[len(run)-1].time)
+			forward := first.StartChapter > last.StartChapter ||
+				(first.StartChapter == last.StartChapter && first.StartVerse >= last.StartVerse)
+			if ref.Book.Name != runBook || gap > maxBookRunGap*24*time.Hour || !forward {
+				flush()
+			}
+		}
+		run = append(run, d)
+		runBook, last = ref.Book.Name, first
+	}
+	flush()
+
+	sort.SliceStable(series, func(i, j int) bool {
+		a, _ := time.Parse(displayDateLayout, series[i].EndDate)
+		b, _ := time.Parse(displayDateLayout, series[j].EndDate)
+		return a.After(b)
+	})
+	return series, nil
+}
+
+// newSeries creates a series of devotionals already in series order
+func newSeries(id, title, kind string, members []seriesDevotional) models.Series {
+	s := models.Series{ID: id, Title: title, Kind: kind, Count: len(members)}
+	var start, end seriesDevotional
+	for i, m := range members {
+		s.Entries = append(s.Entries, m.entry)
+		if i == 0 || m.time.Before(start.time) {
+			start = m
+		}
+		if i == 0 || m.time.After(end.time) {
+			end = m
+		}
+	}
+	s.StartDate, s.EndDate = start.entry.Date, end.entry.Date
+	return s
+}
+
+// seriesSlug turns a series name into its ID, e.g. "Fruit of the Spirit"
+// into "fruit-of-the-spirit". A leading "the" is dropped, so "The Fruit of
+// the Spirit" is the same series.
+func seriesSlug(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, "-")
+}
@@ -1,51 +1,145 @@
 package facebook
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"lwnra-devo-api/models"
 )
 
+// graphAPIBase is the versioned Graph API endpoint used for all requests
+const graphAPIBase = "https://graph.facebook.com/v23.0"
+
+// postFields are the post fields requested from the Graph API
+const postFields = "id,message,created_time,full_picture,attachments{type,url,media,subattachments}"
+
+// engagementFields requests only the summary counts of a post's reactions, comments and shares
+const engagementFields = "reactions.summary(total_count).limit(0),comments.summary(total_count).limit(0),shares"
+
+// maxImageSize is the largest image that will be downloaded (10 MB)
+const maxImageSize = 10 << 20
+
 // Client handles Facebook API interactions
 type Client struct {
-	accessToken string
+	baseURL string
+
+	mu          sync.Mutex
+	credentials []Credential
+	active      int
 }
 
 // New creates a new Facebook client
 func New(accessToken string) *Client {
+	var creds []Credential
+	if accessToken != "" {
+		creds = append(creds, Credential{Label: "primary", Token: accessToken})
+	}
+	return NewWithCredentials(creds)
+}
+
+// NewWithCredentials creates a Facebook client that tries the given credentials
+// in order, failing over to the next one when the Graph API rejects a token
+func NewWithCredentials(credentials []Credential) *Client {
 	return &Client{
-		accessToken: accessToken,
+		baseURL:     graphAPIBase,
+		credentials: credentials,
 	}
 }
 
 // GetRecentPosts fetches recent posts from Facebook API
 func (c *Client) GetRecentPosts() ([]models.FBPost, error) {
-	url := fmt.Sprintf(
-		"https://graph.facebook.com/v23.0/me?fields=id,name,posts{message,created_time}&access_token=%s",
-		c.accessToken,
-	)
+	params := url.Values{"fields": {"id,name,posts{" + postFields + "}"}}
+
+	var fb models.FBMeResponse
+	if err := c.get("/me", params, &fb); err != nil {
+		return nil, err
+	}
+
+	return fb.Posts.Data, nil
+}
+
+// GetPost fetches a single post by its ID
+func (c *Client) GetPost(postID string) (models.FBPost, error) {
+	params := url.Values{"fields": {postFields}}
+
+	var post models.FBPost
+	if err := c.get("/"+url.PathEscape(postID), params, &post); err != nil {
+		return models.FBPost{}, err
+	}
+
+	return post, nil
+}
 
-	resp, err := http.Get(url)
+// GetPostEngagement fetches the reaction, comment and share counts of a post
+func (c *Client) GetPostEngagement(postID string) (models.Engagement, error) {
+	params := url.Values{"fields": {engagementFields}}
+
+	var fb models.FBEngagementResponse
+	if err := c.get("/"+url.PathEscape(postID), params, &fb); err != nil {
+		return models.Engagement{}, err
+	}
+
+	return models.Engagement{
+		Reactions: fb.Reactions.Summary.TotalCount,
+		Comments:  fb.Comments.Summary.TotalCount,
+		Shares:    fb.Shares.Count,
+	}, nil
+}
+
+// DownloadImage downloads an image from the Facebook CDN and returns its content and content type
+func (c *Client) DownloadImage(imageURL string) ([]byte, string, error) {
+	resp, err := http.Get(imageURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make Facebook API request: %w", err)
+		return nil, "", fmt.Errorf("failed to download image: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Facebook API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, "", fmt.Errorf("image download failed with status %d", resp.StatusCode)
 	}
 
-	var fb models.FBMeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fb); err != nil {
-		return nil, fmt.Errorf("failed to decode Facebook API response: %w", err)
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, "", fmt.Errorf("image exceeds %d bytes", maxImageSize)
 	}
 
-	return fb.Posts.Data, nil
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("unexpected content type %q", contentType)
+	}
+
+	return data, contentType, nil
+}
+
+// PostImageURLs returns the image URLs of a post, starting with its full picture.
+// Photos inside albums (subattachments) are included and duplicates are dropped.
+func PostImageURLs(post models.FBPost) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(u string) {
+		if u != "" && !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+
+	add(post.FullPicture)
+	for _, a := range post.Attachments.Data {
+		add(a.Media.Image.Src)
+		for _, sub := range a.Subattachments.Data {
+			add(sub.Media.Image.Src)
+		}
+	}
+
+	return urls
 }
 
 // FilterDevotionalPosts filters posts to only include daily devotionals from today or yesterday
@@ -56,7 +150,7 @@ func FilterDevotionalPosts(posts []models.FBPost) []models.FBPost {
 
 	var filtered []models.FBPost
 	for _, post := range posts {
-		if !isDevotionalPost(post.Message) {
+		if !IsDevotionalPost(post.Message) {
 			continue
 		}
 
@@ -69,8 +163,8 @@ func FilterDevotionalPosts(posts []models.FBPost) []models.FBPost {
 	return filtered
 }
 
-// isDevotionalPost checks if a post is a daily devotional
-func isDevotionalPost(message string) bool {
+// IsDevotionalPost checks if a post is a daily devotional
+func IsDevotionalPost(message string) bool {
 	return len(message) > 0 && (message[:min(16, len(message))] == "DAILY DEVOTIONAL")
 }
 
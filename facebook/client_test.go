@@ -0,0 +1,59 @@
+package facebook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCredentialFailover(t *testing.T) {
+	var tried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("access_token")
+		tried = append(tried, token)
+
+		switch token {
+		case "revoked":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"Error validating access token","type":"OAuthException","code":190,"error_subcode":460}}`))
+		case "rate-limited":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"Application request limit reached","type":"OAuthException","code":4}}`))
+		default:
+			w.Write([]byte(`{"id":"1","name":"Living Word NRA","posts":{"data":[{"id":"1_2","message":"DAILY DEVOTIONAL"}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithCredentials(ParseCredentials("revoked", "page:good"))
+	client.baseURL = server.URL
+
+	posts, err := client.GetRecentPosts()
+	if err != nil {
+		t.Fatalf("Expected failover to succeed, got %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != "1_2" {
+		t.Errorf("Unexpected posts: %+v", posts)
+	}
+	if got := client.ActiveCredential(); got != "page" {
+		t.Errorf("Expected active credential 'page', got %q", got)
+	}
+
+	// The credential that succeeded is tried first from now on
+	tried = nil
+	client.GetRecentPosts()
+	if len(tried) != 1 || tried[0] != "good" {
+		t.Errorf("Expected only the active credential to be tried, got %v", tried)
+	}
+
+	// Rate limits are not a credential problem and must not fail over
+	client = NewWithCredentials(ParseCredentials("rate-limited", "good"))
+	client.baseURL = server.URL
+	tried = nil
+	if _, err := client.GetRecentPosts(); err == nil {
+		t.Error("Expected rate limit error")
+	}
+	if len(tried) != 1 {
+		t.Errorf("Expected no failover on rate limit, tried %v", tried)
+	}
+}
@@ -0,0 +1,169 @@
+package facebook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrNoCredentials is returned when the client has no access token configured
+var ErrNoCredentials = errors.New("no Facebook access token configured")
+
+// Credential is an access token the client can authenticate with
+type Credential struct {
+	Label string // e.g. "system_user", "page", "user"
+	Token string
+}
+
+// ParseCredentials builds the ordered credential list from the primary token and
+// a comma-separated list of fallbacks. Fallbacks may be labeled as "label:token".
+func ParseCredentials(primary, fallbacks string) []Credential {
+	var creds []Credential
+	seen := make(map[string]bool)
+	add := func(label, token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			creds = append(creds, Credential{Label: label, Token: token})
+		}
+	}
+
+	add("primary", strings.TrimSpace(primary))
+	for i, entry := range strings.Split(fallbacks, ",") {
+		entry = strings.TrimSpace(entry)
+		label := fmt.Sprintf("fallback-%d", i+1)
+		if idx := strings.Index(entry, ":"); idx > 0 {
+			label, entry = entry[:idx], entry[idx+1:]
+		}
+		add(label, strings.TrimSpace(entry))
+	}
+
+	return creds
+}
+
+// GraphError is an error returned by the Graph API
+type GraphError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+	Type       string `json:"type"`
+	Code       int    `json:"code"`
+	Subcode    int    `json:"error_subcode"`
+	Body       string `json:"-"`
+}
+
+func (e *GraphError) Error() string {
+	return fmt.Sprintf("Facebook API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// IsCredentialError reports whether the error means the token itself was rejected
+// (invalid, expired, revoked or missing permissions), so another token may succeed.
+// Rate limits and other errors are not credential errors.
+func (e *GraphError) IsCredentialError() bool {
+	if e.Type != "OAuthException" {
+		return false
+	}
+	switch {
+	case e.Code == 102 || e.Code == 190: // session / access token invalid or expired
+		return true
+	case e.Code == 10 || (e.Code >= 200 && e.Code <= 299): // permission errors
+		return true
+	}
+	return false
+}
+
+// HasCredentials reports whether at least one access token is configured
+func (c *Client) HasCredentials() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.credentials) > 0
+}
+
+// ActiveCredential returns the label of the credential that last succeeded (or
+// will be tried first), or "" if none is configured
+func (c *Client) ActiveCredential() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.credentials) == 0 {
+		return ""
+	}
+	return c.credentials[c.active].Label
+}
+
+// get performs a Graph API GET request and decodes the JSON response into out.
+// Starting from the active credential, it fails over to the next credential
+// whenever the token is rejected, and remembers the one that succeeded.
+func (c *Client) get(path string, params url.Values, out interface{}) error {
+	c.mu.Lock()
+	creds := c.credentials
+	start := c.active
+	c.mu.Unlock()
+
+	if len(creds) == 0 {
+		return ErrNoCredentials
+	}
+
+	var lastErr error
+	for n := 0; n < len(creds); n++ {
+		i := (start + n) % len(creds)
+		cred := creds[i]
+
+		err := c.getWithToken(path, params, cred.Token, out)
+		if err == nil {
+			if i != start {
+				c.mu.Lock()
+				c.active = i
+				c.mu.Unlock()
+				log.Printf("Facebook credential %q rejected, now using %q", creds[start].Label, cred.Label)
+			}
+			return nil
+		}
+
+		var graphErr *GraphError
+		if !errors.As(err, &graphErr) || !graphErr.IsCredentialError() {
+			return err
+		}
+
+		log.Printf("Facebook credential %q failed: %s (code %d)", cred.Label, graphErr.Message, graphErr.Code)
+		lastErr = err
+	}
+
+	return fmt.Errorf("all %d Facebook credentials failed: %w", len(creds), lastErr)
+}
+
+// getWithToken performs a single Graph API GET request with the given token
+func (c *Client) getWithToken(path string, params url.Values, token string, out interface{}) error {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("access_token", token)
+
+	resp, err := http.Get(c.baseURL + path + "?" + query.Encode())
+	if err != nil {
+		return fmt.Errorf("failed to make Facebook API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		graphErr := &GraphError{StatusCode: resp.StatusCode, Body: string(body)}
+
+		var envelope struct {
+			Error *GraphError `json:"error"`
+		}
+		envelope.Error = graphErr
+		json.Unmarshal(body, &envelope)
+
+		return graphErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Facebook API response: %w", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// DefaultPageID is the Living Word NRA page ID
+const DefaultPageID = "164421594332429"
+
 // TokenManager handles Facebook token refresh
 type TokenManager struct {
 	appID        string
@@ -30,7 +33,7 @@ func NewTokenManager(appID, appSecret, initialToken string) *TokenManager {
 		appID:        appID,
 		appSecret:    appSecret,
 		currentToken: initialToken,
-		pageID:       "164421594332429", // Living Word NRA page ID
+		pageID:       DefaultPageID,
 	}
 }
 
@@ -38,9 +41,10 @@ func NewTokenManager(appID, appSecret, initialToken string) *TokenManager {
 func (tm *TokenManager) RefreshToken() (string, error) {
 	// Exchange current token for long-lived token
 	apiURL := fmt.Sprintf(
-		"https://graph.facebook.com/oauth/access_token?grant_type=fb_exchange_token&client_id=%s&client_secret=%s&fb_exchange_token=%s",
-		tm.appID,
-		tm.appSecret,
+		"%s/oauth/access_token?grant_type=fb_exchange_token&client_id=%s&client_secret=%s&fb_exchange_token=%s",
+		graphAPIBase,
+		url.QueryEscape(tm.appID),
+		url.QueryEscape(tm.appSecret),
 		url.QueryEscape(tm.currentToken),
 	)
 
@@ -67,8 +71,9 @@ func (tm *TokenManager) RefreshToken() (string, error) {
 // GetPageToken gets a long-lived page access token
 func (tm *TokenManager) GetPageToken(pageID string) (string, error) {
 	apiURL := fmt.Sprintf(
-		"https://graph.facebook.com/%s?fields=access_token&access_token=%s",
-		pageID,
+		"%s/%s?fields=access_token&access_token=%s",
+		graphAPIBase,
+		url.PathEscape(pageID),
 		url.QueryEscape(tm.currentToken),
 	)
 
@@ -98,7 +103,8 @@ func (tm *TokenManager) GetPageToken(pageID string) (string, error) {
 // ValidateToken checks if current token is still valid
 func (tm *TokenManager) ValidateToken() (bool, error) {
 	apiURL := fmt.Sprintf(
-		"https://graph.facebook.com/me?access_token=%s",
+		"%s/me?access_token=%s",
+		graphAPIBase,
 		url.QueryEscape(tm.currentToken),
 	)
 
@@ -113,11 +119,18 @@ func (tm *TokenManager) ValidateToken() (bool, error) {
 
 // GetTokenInfo returns information about the current token
 func (tm *TokenManager) GetTokenInfo() (map[string]interface{}, error) {
-	// Use debug_token endpoint for more detailed info
+	// Use debug_token endpoint for more detailed info. An app access token can
+	// inspect any token of the app, so prefer it when the app credentials are known.
+	inspector := tm.currentToken
+	if tm.appID != "" && tm.appSecret != "" {
+		inspector = tm.appID + "|" + tm.appSecret
+	}
+
 	apiURL := fmt.Sprintf(
-		"https://graph.facebook.com/debug_token?input_token=%s&access_token=%s",
-		url.QueryEscape(tm.currentToken),
+		"%s/debug_token?input_token=%s&access_token=%s",
+		graphAPIBase,
 		url.QueryEscape(tm.currentToken),
+		url.QueryEscape(inspector),
 	)
 
 	resp, err := http.Get(apiURL)
@@ -5,4 +5,5 @@ go 1.21
 require (
 	github.com/mattn/go-sqlite3 v1.14.30
 	github.com/robfig/cron/v3 v3.0.1
+	golang.org/x/text v0.22.0
 )
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/importer"
+	"lwnra-devo-api/storage"
+)
+
+// AdminHandler handles the /api/admin endpoints
+type AdminHandler struct {
+	db         *database.DB
+	fbClient   *facebook.Client
+	importer   *importer.Importer
+	adminToken string
+}
+
+// NewAdminHandler creates a new admin handler. When adminToken is empty the
+// admin endpoints are disabled.
+func NewAdminHandler(db *database.DB, fbClient *facebook.Client, images *storage.ImageStore, adminToken string) *AdminHandler {
+	return &AdminHandler{
+		db:         db,
+		fbClient:   fbClient,
+		importer:   importer.New(db, fbClient, images),
+		adminToken: adminToken,
+	}
+}
+
+// Authorize checks the request's bearer token and writes a 401 response if it
+// doesn't match, or a 503 response when no admin token is configured
+func (h *AdminHandler) Authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		w.Header().Set("Content-Type", "application/json")
+		respondWithError(w, http.StatusServiceUnavailable, "Admin endpoints are disabled: ADMIN_TOKEN is not set", nil)
+		return false
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1 {
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	respondWithError(w, http.StatusUnauthorized, "Invalid or missing admin token", nil)
+	return false
+}
+
+// SyncPost handles POST /api/admin/posts/{fbPostID}/sync
+func (h *AdminHandler) SyncPost(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	postID := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/api/admin/posts/"), "/sync")
+	if postID == "" || strings.Contains(postID, "/") {
+		respondWithError(w, http.StatusBadRequest, "Invalid Facebook post ID", nil)
+		return
+	}
+
+	post, err := h.fbClient.GetPost(postID)
+	if err != nil {
+		respondWithError(w, http.StatusBadGateway, "Failed to fetch Facebook post", err)
+		return
+	}
+
+	devo, warnings, err := h.importer.ResyncPost(post)
+	if errors.Is(err, importer.ErrNotDevotional) {
+		respondWithError(w, http.StatusUnprocessableEntity, "Post is not a daily devotional", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to save devotional", err)
+		return
+	}
+
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	respondWithSuccess(w, "Post synced successfully", map[string]interface{}{
+		"devotional": devo,
+		"warnings":   warnings,
+	})
+}
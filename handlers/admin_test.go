@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAuthorize(t *testing.T) {
+	handler := NewAdminHandler(nil, nil, nil, "s3cret")
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"Bearer s3cret", true},
+		{"Bearer wrong", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/posts/1_2/sync", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		w := httptest.NewRecorder()
+
+		if got := handler.Authorize(w, req); got != tt.want {
+			t.Errorf("Authorize(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+		if !tt.want && w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 for %q, got %d", tt.header, w.Code)
+		}
+	}
+
+	// Without a configured token the admin endpoints are closed to everyone
+	handler = NewAdminHandler(nil, nil, nil, "")
+	for _, header := range []string{"", "Bearer ", "Bearer s3cret"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/posts/1_2/sync", nil)
+		req.Header.Set("Authorization", header)
+		w := httptest.NewRecorder()
+		if handler.Authorize(w, req) || w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503 for %q without an admin token, got %d", header, w.Code)
+		}
+	}
+}
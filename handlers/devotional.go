@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,20 +10,27 @@ import (
 
 	"lwnra-devo-api/database"
 	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/importer"
+	"lwnra-devo-api/models"
 	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
 )
 
 // DevotionalHandler handles all devotional-related API endpoints
 type DevotionalHandler struct {
 	db       *database.DB
 	fbClient *facebook.Client
+	images   *storage.ImageStore
+	importer *importer.Importer
 }
 
 // NewDevotionalHandler creates a new devotional handler
-func NewDevotionalHandler(db *database.DB, fbClient *facebook.Client) *DevotionalHandler {
+func NewDevotionalHandler(db *database.DB, fbClient *facebook.Client, images *storage.ImageStore) *DevotionalHandler {
 	return &DevotionalHandler{
 		db:       db,
 		fbClient: fbClient,
+		images:   images,
+		importer: importer.New(db, fbClient, images),
 	}
 }
 
@@ -47,12 +55,35 @@ func (h *DevotionalHandler) GetDevotionals(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	devotionals, err := h.db.GetDevotionals(limit)
+	lang := r.URL.Query().Get("lang")
+	if lang != "" && !parser.IsLanguage(lang) {
+		respondWithError(w, http.StatusBadRequest, "Unknown language: "+lang+". Use "+strings.Join(parser.Languages, ", "), nil)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if !validFormat(format) {
+		respondWithError(w, http.StatusBadRequest, "Unknown format: "+format+". Use "+FormatHTML+" or "+FormatMarkdown, nil)
+		return
+	}
+
+	// Stored text is normalized, so the search text is too
+	search := strings.TrimSpace(parser.NormalizeText(r.URL.Query().Get("q")))
+
+	devotionals, err := h.db.GetDevotionals(limit, lang, search)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch devotionals", err)
 		return
 	}
 
+	if format != "" {
+		formatted := make([]formattedDevotional, len(devotionals))
+		for i, devo := range devotionals {
+			formatted[i] = formatDevotional(devo, format)
+		}
+		respondWithSuccess(w, "Devotionals retrieved successfully", formatted)
+		return
+	}
 	respondWithSuccess(w, "Devotionals retrieved successfully", devotionals)
 }
 
@@ -67,15 +98,65 @@ func (h *DevotionalHandler) GetDevotionalByDate(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	devotional, err := h.db.GetDevotionalByDate(date)
+	format := r.URL.Query().Get("format")
+	if !validFormat(format) {
+		respondWithError(w, http.StatusBadRequest, "Unknown format: "+format+". Use "+FormatHTML+" or "+FormatMarkdown, nil)
+		return
+	}
+
+	devotional, err := h.db.GetDevotionalByDate(displayDate(date))
 	if err != nil {
 		respondWithError(w, http.StatusNotFound, "Devotional not found for the specified date", err)
 		return
 	}
 
+	if format != "" {
+		respondWithSuccess(w, "Devotional retrieved successfully", formatDevotional(*devotional, format))
+		return
+	}
 	respondWithSuccess(w, "Devotional retrieved successfully", devotional)
 }
 
+// GetDevotionalImage handles GET /api/devotionals/{date}/image
+func (h *DevotionalHandler) GetDevotionalImage(w http.ResponseWriter, r *http.Request) {
+	date := extractDateFromPath(r.URL.Path)
+	if date == "" {
+		w.Header().Set("Content-Type", "application/json")
+		respondWithError(w, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD", nil)
+		return
+	}
+
+	image, err := h.db.GetDevotionalImage(displayDate(date))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		respondWithError(w, http.StatusNotFound, "No image found for the specified date", err)
+		return
+	}
+
+	f, err := h.images.Open(image.Hash)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		respondWithError(w, http.StatusNotFound, "Image file is missing", err)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		respondWithError(w, http.StatusInternalServerError, "Failed to read image", err)
+		return
+	}
+
+	// Images are content-addressed, so the hash doubles as a strong ETag.
+	// The date URL can point to a new image after a resync, so keep max-age short
+	// and let clients revalidate with the ETag.
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Header().Set("ETag", `"`+image.Hash+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
+
 // SyncDevotionals handles POST /api/devotionals/sync
 func (h *DevotionalHandler) SyncDevotionals(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -91,33 +172,25 @@ func (h *DevotionalHandler) SyncDevotionals(w http.ResponseWriter, r *http.Reque
 	devotionalPosts := facebook.FilterDevotionalPosts(posts)
 
 	// Process and save devotionals
-	count := 0
+	count, pending := 0, 0
 	var errors []string
 
 	for _, post := range devotionalPosts {
-		devo := parser.ParseDevotional(post.Message)
-
-		// Use post date if devotional date is empty
-		if devo.Date == "" {
-			postDate := extractAndFormatPostDate(post.CreatedTime)
-			if postDate != "" {
-				devo.Date = postDate
-			} else {
-				devo.Date = time.Now().Format("January 2, 2006")
-			}
-		}
-
-		// Save to database
-		if err := h.db.SaveDevotional(devo); err != nil {
+		devo, err := h.importer.ImportPost(post)
+		if err != nil {
 			errors = append(errors, "Failed to save devotional '"+devo.Title+"': "+err.Error())
-		} else {
-			count++
+			continue
+		}
+		count++
+		if devo.Status == models.StatusPendingReview {
+			pending++
 		}
 	}
 
 	response := map[string]interface{}{
-		"synced_count": count,
-		"total_posts":  len(devotionalPosts),
+		"synced_count":  count,
+		"pending_count": pending,
+		"total_posts":   len(devotionalPosts),
 	}
 
 	if len(errors) > 0 {
@@ -131,24 +204,81 @@ func (h *DevotionalHandler) SyncDevotionals(w http.ResponseWriter, r *http.Reque
 func (h *DevotionalHandler) ParseDevotional(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	var request struct {
-		Message string `json:"message"`
+	message, ok := decodeMessage(w, r)
+	if !ok {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid JSON request body", err)
-		return
+	// Parse the devotional along with per-field diagnostics
+	result := parser.Parse(message)
+
+	response := struct {
+		models.Devotional
+		DateTime *time.Time                     `json:"date_time,omitempty"`
+		Fields   map[string]*parser.FieldResult `json:"fields"`
+		Warnings []string                       `json:"warnings"`
+	}{
+		Devotional: result.Devotional,
+		DateTime:   result.DateTime,
+		Fields:     result.Fields,
+		Warnings:   result.Warnings,
 	}
 
-	if request.Message == "" {
-		respondWithError(w, http.StatusBadRequest, "Message field is required", nil)
+	respondWithSuccess(w, "Devotional parsed successfully", response)
+}
+
+// ValidateDevotional handles POST /api/devotionals/validate
+func (h *DevotionalHandler) ValidateDevotional(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	message, ok := decodeMessage(w, r)
+	if !ok {
 		return
 	}
 
-	// Parse the devotional
-	devo := parser.ParseDevotional(request.Message)
+	// A draft with errors is still a successful validation
+	respondWithSuccess(w, "Devotional validated", parser.Validate(message))
+}
+
+// maxRequestBytes caps the size of request bodies. JSON escapes can take up
+// to six bytes for a byte of text, so a message at the parser's limit fits.
+const maxRequestBytes = 6 * parser.MaxMessageBytes
+
+// decodeJSON decodes a request body of at most maxRequestBytes, writing an
+// error response when it can't
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Request body is too large", err)
+		} else {
+			respondWithError(w, http.StatusBadRequest, "Invalid JSON request body", err)
+		}
+		return false
+	}
+	return true
+}
+
+// decodeMessage decodes a {"message": ...} request body, rejecting messages
+// over the parser's limits
+func decodeMessage(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var request struct {
+		Message string `json:"message"`
+	}
+	if !decodeJSON(w, r, &request) {
+		return "", false
+	}
 
-	respondWithSuccess(w, "Devotional parsed successfully", devo)
+	if request.Message == "" {
+		respondWithError(w, http.StatusBadRequest, "Message field is required", nil)
+		return "", false
+	}
+	if err := parser.CheckLimits(request.Message); err != nil {
+		respondWithError(w, http.StatusRequestEntityTooLarge, "Message is too large", err)
+		return "", false
+	}
+	return request.Message, true
 }
 
 // Helper functions
@@ -183,10 +313,12 @@ func extractDateFromPath(path string) string {
 	return ""
 }
 
-func extractAndFormatPostDate(createdTime string) string {
-	t, err := time.Parse("2006-01-02T15:04:05-0700", createdTime)
+// displayDate converts a YYYY-MM-DD path date into the "January 2, 2006"
+// format devotionals are stored with. Other values are returned unchanged.
+func displayDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return ""
+		return date
 	}
 	return t.Format("January 2, 2006")
 }
@@ -5,17 +5,24 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"lwnra-devo-api/database"
 	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/importer"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
 )
 
 func TestParseDevotional(t *testing.T) {
 	// Create test handler
 	db, _ := database.New(":memory:")
 	fbClient := facebook.New("")
-	handler := NewDevotionalHandler(db, fbClient)
+	handler := NewDevotionalHandler(db, fbClient, storage.NewImageStore(t.TempDir()))
 
 	// Test request
 	requestBody := map[string]string{
@@ -61,4 +68,383 @@ What spiritual habit do you do partly for others to notice?`,
 	if devotionalData["date"] != "August 2, 2025" {
 		t.Errorf("Expected date 'August 2, 2025', got %v", devotionalData["date"])
 	}
+
+	// Diagnostics tell a missing section apart from an empty one
+	fields := devotionalData["fields"].(map[string]interface{})
+	reading := fields["reading"].(map[string]interface{})
+	if reading["confidence"] != 1.0 {
+		t.Errorf("Expected reading confidence 1, got %v", reading["confidence"])
+	}
+	if span := reading["span"].(map[string]interface{}); span["start_line"] != 2.0 {
+		t.Errorf("Expected reading on line 2, got %v", span["start_line"])
+	}
+
+	prayer := fields["prayer"].(map[string]interface{})
+	if prayer["confidence"] != 0.0 || prayer["span"] != nil {
+		t.Errorf("Expected missing prayer to have zero confidence and no span, got %v", prayer)
+	}
+
+	warnings := devotionalData["warnings"].([]interface{})
+	found := false
+	for _, w := range warnings {
+		if w == "prayer: no PRAYER header found" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a missing PRAYER warning, got %v", warnings)
+	}
+}
+
+func TestParseDevotionalLimits(t *testing.T) {
+	db, _ := database.New(":memory:")
+	handler := NewDevotionalHandler(db, facebook.New(""), storage.NewImageStore(t.TempDir()))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"too many lines", `{"message":"` + strings.Repeat(`Amen\n`, parser.MaxMessageLines) + `"}`, http.StatusRequestEntityTooLarge},
+		{"too many bytes", `{"message":"` + strings.Repeat("a", parser.MaxMessageBytes+1) + `"}`, http.StatusRequestEntityTooLarge},
+		{"body over the request limit", `{"message":"` + strings.Repeat("a", maxRequestBytes) + `"}`, http.StatusRequestEntityTooLarge},
+		{"invalid JSON", `{"message":`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/devotionals/parse", strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		handler.ParseDevotional(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+}
+
+func TestGetDevotionalImage(t *testing.T) {
+	db, _ := database.New(":memory:")
+	images := storage.NewImageStore(t.TempDir())
+	handler := NewDevotionalHandler(db, facebook.New(""), images)
+
+	devo := models.Devotional{Date: "August 9, 2025", Reading: "Psalm 71:1-3", Title: "A REFUGE IN EVERY SEASON", Body: "God is our refuge."}
+	if err := db.SaveDevotional(&devo); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+
+	png := []byte("\x89PNG\r\n\x1a\n fake image")
+	hash, err := images.Put(png)
+	if err != nil {
+		t.Fatalf("Failed to store image: %v", err)
+	}
+	err = db.SaveDevotionalImages(devo.Date, devo.Title, []models.DevotionalImage{
+		{Position: 0, Hash: hash, ContentType: "image/png", SourceURL: "https://example.com/a.png"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to link image: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09/image", nil)
+	w := httptest.NewRecorder()
+	handler.GetDevotionalImage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("Expected content type image/png, got %q", got)
+	}
+	if !bytes.Equal(w.Body.Bytes(), png) {
+		t.Errorf("Served image does not match stored image")
+	}
+
+	// A matching ETag should short-circuit with 304
+	req = httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09/image", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	handler.GetDevotionalImage(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", w.Code)
+	}
+
+	// A resync whose first image failed to download leaves only the second
+	// one, which replaces the old main picture
+	jpeg := []byte("\xff\xd8\xff fake image")
+	newHash, err := images.Put(jpeg)
+	if err != nil {
+		t.Fatalf("Failed to store image: %v", err)
+	}
+	err = db.SaveDevotionalImages(devo.Date, devo.Title, []models.DevotionalImage{
+		{Position: 1, Hash: newHash, ContentType: "image/jpeg", SourceURL: "https://example.com/b.jpg"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to link image: %v", err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09/image", nil)
+	w = httptest.NewRecorder()
+	handler.GetDevotionalImage(w, req)
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), jpeg) {
+		t.Errorf("Expected the image of the resync, got %d %q", w.Code, w.Body.Bytes())
+	}
+}
+
+func TestResyncReplacesImages(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	images := storage.NewImageStore(t.TempDir())
+	fbClient := facebook.New("")
+	handler := NewDevotionalHandler(db, fbClient, images)
+	im := importer.New(db, fbClient, images)
+
+	png := []byte("\x89PNG\r\n\x1a\n fake image")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(png)
+	}))
+	defer server.Close()
+
+	post := models.FBPost{
+		ID:          "1_2",
+		CreatedTime: "2025-08-09T06:00:00+0800",
+		Message:     "DAILY DEVOTIONAL\nRead Psalm 71:1-3\nAugust 9, 2025\nPsalm 71:1-3 NIV\n1 In you, LORD, I have taken refuge.\nREFLECTION QUESTIONS\nWhere do you run?\nA REFUGE IN EVERY SEASON\nGrace Dela Cruz\nGod is our refuge.\nPRAYER\nAmen.",
+	}
+	imageStatus := func() int {
+		w := httptest.NewRecorder()
+		handler.GetDevotionalImage(w, httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09/image", nil))
+		return w.Code
+	}
+	resync := func(picture string) {
+		post.FullPicture = picture
+		if _, _, err := im.ResyncPost(post); err != nil {
+			t.Fatalf("Failed to resync post: %v", err)
+		}
+	}
+
+	resync(server.URL + "/a.png")
+	if code := imageStatus(); code != http.StatusOK {
+		t.Fatalf("Expected the post image, got status %d", code)
+	}
+
+	// Every download failing keeps the old image
+	resync(server.URL + "/missing.png")
+	if code := imageStatus(); code != http.StatusOK {
+		t.Errorf("Expected the old image kept when downloads fail, got status %d", code)
+	}
+
+	// The image was removed from the post
+	resync("")
+	if code := imageStatus(); code != http.StatusNotFound {
+		t.Errorf("Expected no image after the post lost it, got status %d", code)
+	}
+}
+
+func TestGetDevotionalsByLanguage(t *testing.T) {
+	db, _ := database.New(":memory:")
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	for _, devo := range []models.Devotional{
+		{Date: "August 11, 2025", Reading: "John 15:4", Title: "ABIDE IN HIM", Body: "Abide.", Language: "en"},
+		{Date: "August 12, 2025", Reading: "John 15:4", Title: "MANATILI SA KANYA", Body: "Manatili.", Language: "fil"},
+		{Date: "September 3, 2025", Reading: "Philippians 4:7", Title: "PEACE THAT GUARDS", Body: "Peace.", Language: "taglish"},
+	} {
+		if err := db.SaveDevotional(&devo); err != nil {
+			t.Fatalf("Failed to save devotional: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devotionals?lang=fil", nil)
+	w := httptest.NewRecorder()
+	handler.GetDevotionals(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data []models.Devotional `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].Title != "MANATILI SA KANYA" {
+		t.Errorf("Expected only the Filipino devotional, got %+v", response.Data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/devotionals?lang=es", nil)
+	w = httptest.NewRecorder()
+	handler.GetDevotionals(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown language, got %d", w.Code)
+	}
+}
+
+func TestSearchDevotionals(t *testing.T) {
+	db, _ := database.New(":memory:")
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	// Posted with a bold title and smart quotes, stored normalized
+	msg := "DAILY DEVOTIONAL\nRead Micah 6:6-8\nAugust 27, 2025\nMicah 6:6-8 NIV\n8 He has shown you, O mortal, what is good.\nREFLECTION QUESTIONS\nWhat does the LORD require?\n𝐖𝐇𝐀𝐓 𝐓𝐇𝐄 𝐋𝐎𝐑𝐃 𝐑𝐄𝐐𝐔𝐈𝐑𝐄𝐒\nBen Villanueva\nMicah’s listeners asked what would be “enough” for God.\nPRAYER\nAmen.\n\n#DailyDevotional"
+	devo := parser.ParseDevotional(msg)
+	if err := db.SaveDevotional(&devo); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+	if err := db.SaveDevotional(&models.Devotional{Date: "August 28, 2025", Title: "PEACE THAT GUARDS"}); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+
+	for _, q := range []string{"lord requires", "𝐑𝐄𝐐𝐔𝐈𝐑𝐄𝐒", "Micah’s listeners", "\"enough\""} {
+		req := httptest.NewRequest(http.MethodGet, "/api/devotionals?q="+url.QueryEscape(q), nil)
+		w := httptest.NewRecorder()
+		handler.GetDevotionals(w, req)
+
+		var response struct {
+			Data []models.Devotional `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response.Data) != 1 || response.Data[0].Title != "WHAT THE LORD REQUIRES" {
+			t.Errorf("Search %q: expected WHAT THE LORD REQUIRES, got %+v", q, response.Data)
+			continue
+		}
+		if !response.Data[0].Styles["title"].Bold {
+			t.Errorf("Expected the title to be marked bold, got %+v", response.Data[0].Styles)
+		}
+		if devo := response.Data[0]; devo.Prayer != "Amen." || devo.Footer != "#DailyDevotional" || len(devo.Hashtags) != 1 {
+			t.Errorf("Expected the footer stored apart from the prayer, got %q, %q, %v", devo.Prayer, devo.Footer, devo.Hashtags)
+		}
+	}
+}
+
+func TestGetDevotionalFormat(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	msg := "DAILY DEVOTIONAL\nRead Psalm 46:10\nAugust 9, 2025\nPsalm 46:10 NIV\n10 Be still, and know that I am God.\nREFLECTION QUESTIONS\nWhat keeps you busy?\nBE STILL\nBen Villanueva\nStillness is *trust*, not <laziness>.\n\n\"Be still, and know that I am God.\" (v. 10)\nPRAYER\nAmen."
+	devo := parser.ParseDevotional(msg)
+	if err := db.SaveDevotional(&devo); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+	// Stored before bodies were split into blocks
+	if err := db.SaveDevotional(&models.Devotional{Date: "August 10, 2025", Reading: "Psalm 4:8", Title: "REST", Body: "- Sleep\n- Pray"}); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+
+	tests := []struct {
+		path, field, want string
+	}{
+		{"/api/devotionals/2025-08-09?format=html", "body_html", `<p>Stillness is <strong>trust</strong>, not &lt;laziness&gt;.</p>
+<blockquote class="scripture"><p>Be still, and know that I am God.</p><cite>Psalm 46:10</cite></blockquote>`},
+		{"/api/devotionals/2025-08-09?format=markdown", "body_markdown", "Stillness is **trust**, not \\<laziness>.\n\n> Be still, and know that I am God.\n>\n> \u2014 Psalm 46:10"},
+		{"/api/devotionals/2025-08-10?format=html", "body_html", "<ul>\n<li>Sleep</li>\n<li>Pray</li>\n</ul>"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		w := httptest.NewRecorder()
+		handler.GetDevotionalByDate(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", tt.path, w.Code)
+		}
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if got := response.Data[tt.field]; got != tt.want {
+			t.Errorf("%s: expected %s\n%v\ngot\n%v", tt.path, tt.field, tt.want, got)
+		}
+		if response.Data["body"] == "" {
+			t.Errorf("%s: expected the raw body alongside", tt.path)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devotionals?format=html", nil)
+	w := httptest.NewRecorder()
+	handler.GetDevotionals(w, req)
+
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Data) != 2 || list.Data[0]["body_html"] == nil || list.Data[0]["body_blocks"] == nil {
+		t.Errorf("Expected both devotionals with their HTML bodies, got %+v", list.Data)
+	}
+
+	for path, handle := range map[string]http.HandlerFunc{
+		"/api/devotionals?format=pdf":            handler.GetDevotionals,
+		"/api/devotionals/2025-08-09?format=pdf": handler.GetDevotionalByDate,
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		handle(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400 for an unknown format, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestValidateDevotional(t *testing.T) {
+	db, _ := database.New(":memory:")
+	handler := NewDevotionalHandler(db, facebook.New(""), storage.NewImageStore(t.TempDir()))
+
+	// The REFLECTION QUESTIONS header is missing before line 6
+	requestBody := map[string]string{
+		"message": `DAILY DEVOTIONAL
+Read Matthew 6:16
+August 2, 2025
+Matthew 6:16 NIV
+16 When you fast, do not look somber as the hypocrites do, for they disfigure their faces to show men they are fasting.
+What spiritual habit do you do partly for others to notice?
+FASTING IN SECRET
+Pastor Ben Villanueva
+Jesus assumes that His followers will fast.
+PRAYER
+Father, help me to seek Your reward alone.`,
+	}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/devotionals/validate", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+	handler.ValidateDevotional(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data parser.Validation `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.Data.Valid {
+		t.Errorf("Expected the draft to be invalid")
+	}
+	if len(response.Data.Errors) != 1 || response.Data.Errors[0].Line != 6 || response.Data.Errors[0].Rule != parser.RuleRequiredSection {
+		t.Errorf("Expected a missing section error on line 6, got %+v", response.Data.Errors)
+	}
+	if len(response.Data.Warnings) != 1 || response.Data.Warnings[0].Rule != parser.RulePrayerAmen {
+		t.Errorf("Expected a missing Amen warning, got %+v", response.Data.Warnings)
+	}
+
+	// An empty draft is rejected
+	req = httptest.NewRequest(http.MethodPost, "/api/devotionals/validate", bytes.NewBufferString(`{"message":""}`))
+	w = httptest.NewRecorder()
+	handler.ValidateDevotional(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an empty draft, got %d", w.Code)
+	}
 }
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+)
+
+// Body formats of the format query parameter
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// formattedDevotional is a devotional with its body rendered in the format
+// the client asked for
+type formattedDevotional struct {
+	models.Devotional
+	BodyHTML     string `json:"body_html,omitempty"`
+	BodyMarkdown string `json:"body_markdown,omitempty"`
+}
+
+// validFormat reports whether format is empty or a known body format
+func validFormat(format string) bool {
+	return format == "" || format == FormatHTML || format == FormatMarkdown
+}
+
+// formatDevotional renders the body of a devotional. Devotionals stored
+// before bodies were split into blocks are split when rendered.
+func formatDevotional(devo models.Devotional, format string) formattedDevotional {
+	blocks := devo.BodyBlocks
+	if len(blocks) == 0 && devo.Body != "" {
+		blocks = parser.BodyBlocks(devo.Body, devo.Reading)
+	}
+
+	formatted := formattedDevotional{Devotional: devo}
+	switch format {
+	case FormatHTML:
+		formatted.BodyHTML = parser.RenderBodyHTML(blocks)
+	case FormatMarkdown:
+		formatted.BodyMarkdown = parser.RenderBodyMarkdown(blocks)
+	}
+	return formatted
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+)
+
+// overriddenDevotional is a devotional with the overrides applied to it, each
+// with the value the parser gave
+type overriddenDevotional struct {
+	Devotional *models.Devotional     `json:"devotional"`
+	Overrides  []models.FieldOverride `json:"overrides"`
+}
+
+// GetDevotionalOverrides handles GET /api/admin/devotionals/{id}, a
+// devotional, published or not, with its overrides
+func (h *AdminHandler) GetDevotionalOverrides(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devo, ok := h.devotionalFromPath(w, r)
+	if !ok {
+		return
+	}
+	h.respondWithOverrides(w, "Devotional retrieved successfully", devo.ID)
+}
+
+// PatchDevotional handles PATCH /api/admin/devotionals/{id}, overriding fields
+// of a devotional so that parsing its post again keeps them
+func (h *AdminHandler) PatchDevotional(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devo, ok := h.devotionalFromPath(w, r)
+	if !ok {
+		return
+	}
+	if h.saveOverrides(w, r, devo) {
+		h.respondWithOverrides(w, "Devotional updated successfully", devo.ID)
+	}
+}
+
+// devotionalFromPath fetches the devotional the request path names, writing
+// an error response when there is none
+func (h *AdminHandler) devotionalFromPath(w http.ResponseWriter, r *http.Request) (*models.Devotional, bool) {
+	idStr := strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/api/admin/devotionals/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid devotional ID", err)
+		return nil, false
+	}
+
+	devo, err := h.db.GetDevotionalByID(id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Devotional not found", err)
+		return nil, false
+	}
+	return devo, true
+}
+
+// saveOverrides applies the fields of a request body to a stored devotional
+// as overrides, and saves it with the fields derived from them. A field set
+// to null drops its override and gets the parsed value back. A published
+// devotional can't be left without its title, reading or body.
+func (h *AdminHandler) saveOverrides(w http.ResponseWriter, r *http.Request, devo *models.Devotional) bool {
+	var fields map[string]json.RawMessage
+	if !decodeJSON(w, r, &fields) {
+		return false
+	}
+
+	existing, err := h.db.GetOverrides(devo.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch overrides", err)
+		return false
+	}
+	previous := make(map[string]models.FieldOverride)
+	for _, o := range existing {
+		previous[o.Field] = o
+	}
+
+	var set []models.FieldOverride
+	var cleared []string
+	for field := range fields {
+		if !isOverridable(field) {
+			respondWithError(w, http.StatusBadRequest, "Unknown field: "+field+". Use "+strings.Join(models.OverridableFields, ", "), nil)
+			return false
+		}
+	}
+	for _, field := range models.OverridableFields {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+		if string(value) == "null" {
+			if o, ok := previous[field]; ok {
+				if err := devo.SetField(field, o.Parsed); err != nil {
+					respondWithError(w, http.StatusInternalServerError, "Failed to restore the parsed "+field, err)
+					return false
+				}
+				cleared = append(cleared, field)
+			}
+			continue
+		}
+
+		override := models.FieldOverride{Field: field, Override: normalizeOverride(value)}
+		if o, ok := previous[field]; ok {
+			override.Parsed = o.Parsed
+		} else if override.Parsed, err = devo.FieldValue(field); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return false
+		}
+		if err := devo.SetField(field, override.Override); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return false
+		}
+		set = append(set, override)
+	}
+
+	fixed := parser.Derive(*devo)
+	if fixed.Status == models.StatusPublished {
+		if fixed.ApplyQualityGate(); len(fixed.ReviewReasons) > 0 {
+			respondWithError(w, http.StatusUnprocessableEntity, "Devotional would be incomplete: "+strings.Join(fixed.ReviewReasons, ", "), nil)
+			return false
+		}
+	}
+	if err := h.db.UpdateDevotionalOverrides(fixed, set, cleared); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to save devotional", err)
+		return false
+	}
+	return true
+}
+
+// respondWithOverrides responds with a stored devotional and its overrides
+func (h *AdminHandler) respondWithOverrides(w http.ResponseWriter, message string, id int64) {
+	devo, err := h.db.GetDevotionalByID(id)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch devotional", err)
+		return
+	}
+	overrides, err := h.db.GetOverrides(id)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch overrides", err)
+		return
+	}
+	respondWithSuccess(w, message, overriddenDevotional{Devotional: devo, Overrides: overrides})
+}
+
+// normalizeOverride normalizes the text of an override like parsed posts,
+// dropping empty reflection questions. Values of the wrong type are left for
+// SetField to reject.
+func normalizeOverride(value json.RawMessage) json.RawMessage {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		normalized, _ := json.Marshal(strings.TrimSpace(parser.NormalizeText(text)))
+		return normalized
+	}
+	var list []string
+	if err := json.Unmarshal(value, &list); err == nil {
+		kept := []string{}
+		for _, item := range list {
+			if item = strings.TrimSpace(parser.NormalizeText(item)); item != "" {
+				kept = append(kept, item)
+			}
+		}
+		normalized, _ := json.Marshal(kept)
+		return normalized
+	}
+	return value
+}
+
+func isOverridable(field string) bool {
+	for _, f := range models.OverridableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/importer"
+	"lwnra-devo-api/models"
+)
+
+func TestPatchDevotionalOverrides(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	admin := NewAdminHandler(db, nil, nil, "")
+	im := importer.New(db, nil, nil)
+
+	// The author line is misparsed as "By the grace of God"
+	post := models.FBPost{
+		ID:          "1_2",
+		CreatedTime: "2025-08-09T06:00:00+0800",
+		Message:     "DAILY DEVOTIONAL\nRead Psalm 71:1-3\nAugust 9, 2025\nPsalm 71:1-3 NIV\n1 In you, LORD, I have taken refuge.\nREFLECTION QUESTIONS\nWhere do you run?\nA REFUGE IN EVERY SEASON\nBy the grace of God\nGod is our refuge.\nPRAYER\nAmen.",
+	}
+	devo, _, err := im.ResyncPost(post)
+	if err != nil {
+		t.Fatalf("Failed to sync post: %v", err)
+	}
+	id, err := db.FindDevotionalID(post.ID, devo.Date, devo.Title)
+	if err != nil || id == 0 {
+		t.Fatalf("Failed to find the synced devotional: %v", err)
+	}
+	path := fmt.Sprintf("/api/admin/devotionals/%d", id)
+
+	patch := func(body string) (*httptest.ResponseRecorder, overriddenDevotional) {
+		w := httptest.NewRecorder()
+		admin.PatchDevotional(w, httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(body)))
+		var response struct {
+			Data overriddenDevotional `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+		return w, response.Data
+	}
+
+	w, data := patch(`{"author": "Pastor Ben Villanueva"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if data.Devotional.Author != "Pastor Ben Villanueva" {
+		t.Errorf("Expected the overridden author, got %q", data.Devotional.Author)
+	}
+	if len(data.Overrides) != 1 || string(data.Overrides[0].Parsed) != `"By the grace of God"` || string(data.Overrides[0].Override) != `"Pastor Ben Villanueva"` {
+		t.Errorf("Expected the parsed and overridden author side by side, got %+v", data.Overrides)
+	}
+
+	// The post is edited and synced again: the author keeps its override, the
+	// rest comes from the new parse
+	post.Message = post.Message[:len(post.Message)-len("God is our refuge.\nPRAYER\nAmen.")] + "God is our refuge and strength.\nPRAYER\nAmen."
+	if _, warnings, err := im.ResyncPost(post); err != nil || len(warnings) != 1 || warnings[0] != "overridden: author" {
+		t.Fatalf("Expected the resync to report the override, got %v %v", warnings, err)
+	}
+	w = httptest.NewRecorder()
+	admin.GetDevotionalOverrides(w, httptest.NewRequest(http.MethodGet, path, nil))
+	var response struct {
+		Data overriddenDevotional `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if d := response.Data.Devotional; d.Author != "Pastor Ben Villanueva" || d.Body != "God is our refuge and strength." {
+		t.Errorf("Expected the override kept over the new parse, got %q, %q", d.Author, d.Body)
+	}
+
+	// A published devotional can't lose its title
+	if w, _ := patch(`{"title": "  "}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 for an empty title, got %d", w.Code)
+	}
+	if w, _ := patch(`{"fb_post_id": "1_3"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a field that can't be overridden, got %d", w.Code)
+	}
+	if w, _ := patch(`{"reflection_qs": "Where do you run?"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for questions that are not a list, got %d", w.Code)
+	}
+
+	// Dropping the override restores the parsed author
+	w, data = patch(`{"author": null}`)
+	if w.Code != http.StatusOK || data.Devotional.Author != "By the grace of God" || len(data.Overrides) != 0 {
+		t.Errorf("Expected the parsed author back without overrides, got %d %q %+v", w.Code, data.Devotional.Author, data.Overrides)
+	}
+}
+
+func TestImportKeepsOverrides(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	admin := NewAdminHandler(db, nil, nil, "")
+	im := importer.New(db, nil, nil)
+
+	post := models.FBPost{
+		ID:          "1_2",
+		CreatedTime: "2025-08-02T06:00:00+0800",
+		Message:     "DAILY DEVOTIONAL\nRead Matthew 6:16-18\nAugust 2, 2025\nMatthew 6:16-18 NIV\n16 When you fast, do not look somber.\nREFLECTION QUESTIONS\nWhat do you do to be seen?\nFASTING IN SECRET\nPastor Ben Villanueva\nJesus assumes that His followers will fast.\nPRAYER\nAmen.",
+	}
+	devo, err := im.ImportPost(post)
+	if err != nil {
+		t.Fatalf("Failed to import post: %v", err)
+	}
+	id, err := db.FindDevotionalID(post.ID, devo.Date, devo.Title)
+	if err != nil || id == 0 {
+		t.Fatalf("Failed to find the imported devotional: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	admin.PatchDevotional(w, httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/admin/devotionals/%d", id), bytes.NewBufferString(`{"title": "FASTING IN PRIVATE"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	// The scheduled sync imports the post again
+	devo, err = im.ImportPost(post)
+	if err != nil {
+		t.Fatalf("Failed to import post again: %v", err)
+	}
+	if devo.Title != "FASTING IN PRIVATE" {
+		t.Errorf("Expected the import to apply the title override, got %q", devo.Title)
+	}
+	devotionals, err := db.GetDevotionals(10, "", "")
+	if err != nil {
+		t.Fatalf("Failed to fetch devotionals: %v", err)
+	}
+	if len(devotionals) != 1 || devotionals[0].ID != id || devotionals[0].Title != "FASTING IN PRIVATE" {
+		t.Errorf("Expected the one corrected devotional, got %+v", devotionals)
+	}
+	if pending, err := db.GetPendingDevotionals(); err != nil || len(pending) != 0 {
+		t.Errorf("Expected no devotional held for review, got %+v %v", pending, err)
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"lwnra-devo-api/scripture"
+)
+
+// GetCrossReferences handles GET /api/cross-references, the index of passages
+// cited in devotional bodies outside of their own readings
+func (h *DevotionalHandler) GetCrossReferences(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	book := ""
+	if name := r.URL.Query().Get("book"); name != "" {
+		b, ok := scripture.LookupBook(name)
+		if !ok {
+			respondWithError(w, http.StatusBadRequest, "Unknown book: "+name, nil)
+			return
+		}
+		book = b.Name
+	}
+
+	refs, err := h.db.GetCrossReferences(book)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch cross-references", err)
+		return
+	}
+
+	respondWithSuccess(w, "Cross-references retrieved successfully", refs)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
+)
+
+func TestGetCrossReferences(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	posts := []string{
+		"DAILY DEVOTIONAL\nRead Romans 8:28-30\nAugust 5, 2025\nRomans 8:28-30 NLT\n28 And we know...\nREFLECTION QUESTIONS\nWhat is working for good?\nALL THINGS FOR GOOD\nBen Villanueva\nGod works in everything (v. 28), just as He planned (Ephesians 1:11; Jer 29:11).\nPRAYER\nAmen.",
+		"DAILY DEVOTIONAL\nRead Jeremiah 29:10-14\nAugust 6, 2025\nJeremiah 29:10-14 NIV\n10 This is what the LORD says...\nREFLECTION QUESTIONS\nWhat plans do you trust?\nPLANS TO PROSPER\nGrace Dela Cruz\nHis plans are good (v. 11), and He works all things for good (Romans 8:28) (see Ephesians 1:11).\nPRAYER\nAmen.",
+	}
+	for _, msg := range posts {
+		devo := parser.ParseDevotional(msg)
+		if err := db.SaveDevotional(&devo); err != nil {
+			t.Fatalf("Failed to save devotional: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/cross-references", nil)
+	w := httptest.NewRecorder()
+	handler.GetCrossReferences(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data []models.CrossReference `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// In-reading citations ("v. 28" in Romans 8, "v. 11" in Jeremiah 29) are not cross-references
+	refs := response.Data
+	if len(refs) != 3 {
+		t.Fatalf("Expected 3 cross-references, got %+v", refs)
+	}
+	if refs[0].Reference != "Ephesians 1:11" || refs[0].Count != 2 || len(refs[0].Devotionals) != 2 {
+		t.Errorf("Expected Ephesians 1:11 cited twice first, got %+v", refs[0])
+	}
+	if refs[1].Reference != "Jeremiah 29:11" || refs[1].Devotionals[0].Title != "ALL THINGS FOR GOOD" {
+		t.Errorf("Expected Jeremiah 29:11 cited by ALL THINGS FOR GOOD, got %+v", refs[1])
+	}
+	if refs[2].Reference != "Romans 8:28" || refs[2].Devotionals[0].Title != "PLANS TO PROSPER" {
+		t.Errorf("Expected Romans 8:28 cited by PLANS TO PROSPER, got %+v", refs[2])
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/cross-references?book=Eph", nil)
+	w = httptest.NewRecorder()
+	handler.GetCrossReferences(w, req)
+	response.Data = nil
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Data) != 1 || response.Data[0].Book != "Ephesians" {
+		t.Errorf("Expected only Ephesians, got %+v", response.Data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/cross-references?book=Hezekiah", nil)
+	w = httptest.NewRecorder()
+	handler.GetCrossReferences(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown book, got %d", w.Code)
+	}
+}
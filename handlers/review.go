@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lwnra-devo-api/models"
+)
+
+// GetReviewQueue handles GET /api/admin/review, the devotionals held for
+// review, oldest first
+func (h *AdminHandler) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devotionals, err := h.db.GetPendingDevotionals()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch the review queue", err)
+		return
+	}
+
+	respondWithSuccess(w, "Review queue retrieved successfully", devotionals)
+}
+
+// FixReview handles PATCH /api/admin/review/{id}, overriding fields of a
+// devotional held for review. It stays held until it is approved.
+func (h *AdminHandler) FixReview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devo, ok := h.pendingDevotional(w, r)
+	if !ok {
+		return
+	}
+	if h.saveOverrides(w, r, devo) {
+		h.respondWithOverrides(w, "Devotional updated successfully", devo.ID)
+	}
+}
+
+// ApproveReview handles POST /api/admin/review/{id}/approve, publishing a
+// devotional held for review once it has a title, reading and body
+func (h *AdminHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devo, ok := h.pendingDevotional(w, r)
+	if !ok {
+		return
+	}
+	if len(devo.ReviewReasons) > 0 {
+		respondWithError(w, http.StatusUnprocessableEntity, "Devotional is incomplete: "+strings.Join(devo.ReviewReasons, ", "), nil)
+		return
+	}
+
+	if err := h.db.SetDevotionalStatus(devo.ID, models.StatusPublished); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to publish devotional", err)
+		return
+	}
+	devo.Status = models.StatusPublished
+
+	respondWithSuccess(w, "Devotional published successfully", devo)
+}
+
+// pendingDevotional fetches the devotional held for review that the request
+// path names, writing an error response when there is none
+func (h *AdminHandler) pendingDevotional(w http.ResponseWriter, r *http.Request) (*models.Devotional, bool) {
+	idStr := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/api/admin/review/"), "/approve")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid devotional ID", err)
+		return nil, false
+	}
+
+	devo, err := h.db.GetDevotionalByID(id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Devotional not found", err)
+		return nil, false
+	}
+	if devo.Status != models.StatusPendingReview {
+		respondWithError(w, http.StatusConflict, "Devotional is not held for review", nil)
+		return nil, false
+	}
+	return devo, true
+}
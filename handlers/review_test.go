@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
+)
+
+func TestReviewQueue(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	admin := NewAdminHandler(db, nil, nil, "")
+	devotionals := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	// The post lost its title and reading, so the author and body are taken
+	// as questions and it is held for review
+	msg := "DAILY DEVOTIONAL\nAugust 9, 2025\nREFLECTION QUESTIONS\nWhere do you run for refuge?\nPastor Ben Villanueva\nGod does not promise a life without storms.\nPRAYER\nLord, be my refuge. Amen."
+	devo := parser.ParseDevotional(msg)
+	if err := db.SaveDevotional(&devo); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	devotionals.GetDevotionalByDate(w, httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected a devotional held for review to be unpublished, got status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	admin.GetReviewQueue(w, httptest.NewRequest(http.MethodGet, "/api/admin/review", nil))
+	var queue struct {
+		Data []models.Devotional `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&queue); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(queue.Data) != 1 {
+		t.Fatalf("Expected one devotional held for review, got %+v", queue.Data)
+	}
+	held := queue.Data[0]
+	if held.Status != models.StatusPendingReview || !reflect.DeepEqual(held.ReviewReasons, []string{"missing title", "missing reading", "missing body"}) {
+		t.Errorf("Expected the missing title, reading and body as reasons, got %q %v", held.Status, held.ReviewReasons)
+	}
+	path := fmt.Sprintf("/api/admin/review/%d", held.ID)
+
+	// It can't be approved until it is fixed
+	w = httptest.NewRecorder()
+	admin.ApproveReview(w, httptest.NewRequest(http.MethodPost, path+"/approve", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 approving an incomplete devotional, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	admin.FixReview(w, httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(`{"location": "Manila"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown field, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	fix := `{"title": "A REFUGE IN EVERY SEASON", "reading": "Psalm 71:1-3", "author": "Pastor Ben Villanueva",
+		"reflection_qs": ["Where do you run for refuge?"], "body": "God does not promise a life without storms (v. 3)."}`
+	admin.FixReview(w, httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(fix)))
+	var fixed struct {
+		Data struct {
+			Devotional models.Devotional      `json:"devotional"`
+			Overrides  []models.FieldOverride `json:"overrides"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&fixed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(fixed.Data.Overrides) != 5 {
+		t.Errorf("Expected the fixes stored as 5 overrides, got %+v", fixed.Data.Overrides)
+	}
+	if d := fixed.Data.Devotional; d.Title != "A REFUGE IN EVERY SEASON" || d.Author != "Pastor Ben Villanueva" || len(d.ReflectionQs) != 1 || len(d.ReviewReasons) != 0 || d.Status != models.StatusPendingReview {
+		t.Errorf("Expected the fixed devotional still held for review, got %+v", d)
+	}
+	if c := fixed.Data.Devotional.Citations; len(c) != 1 || c[0].Reference != "Psalm 71:3" {
+		t.Errorf("Expected the citation resolved against the fixed reading, got %+v", c)
+	}
+
+	w = httptest.NewRecorder()
+	admin.ApproveReview(w, httptest.NewRequest(http.MethodPost, path+"/approve", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 approving the fixed devotional, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	devotionals.GetDevotionalByDate(w, httptest.NewRequest(http.MethodGet, "/api/devotionals/2025-08-09", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected the approved devotional to be published, got status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	admin.ApproveReview(w, httptest.NewRequest(http.MethodPost, path+"/approve", nil))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 approving a published devotional, got %d", w.Code)
+	}
+}
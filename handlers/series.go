@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GetSeries handles GET /api/series, the series the devotionals are grouped
+// into, most recent first
+func (h *DevotionalHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	series, err := h.db.GetSeries()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch series", err)
+		return
+	}
+
+	respondWithSuccess(w, "Series retrieved successfully", series)
+}
+
+// GetSeriesByID handles GET /api/series/{id}, a series with its devotionals
+// in order
+func (h *DevotionalHandler) GetSeriesByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id := strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/api/series/")
+	if id == "" || strings.Contains(id, "/") {
+		respondWithError(w, http.StatusBadRequest, "Invalid series ID", nil)
+		return
+	}
+
+	series, err := h.db.GetSeriesByID(id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Series not found", err)
+		return
+	}
+
+	respondWithSuccess(w, "Series retrieved successfully", series)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
+)
+
+func TestGetSeries(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	post := func(reading, date, title, body string) string {
+		return fmt.Sprintf("DAILY DEVOTIONAL\nRead %s\n%s\n%s NIV\n1 Verse text.\nREFLECTION QUESTIONS\nWhat now?\n%s\nGrace Dela Cruz\n%s\nPRAYER\nAmen.",
+			reading, date, reading, title, body)
+	}
+	posts := []string{
+		// A named series, stored out of order and named in the body of part 3
+		post("Galatians 5:22", "September 2, 2025", "FRUIT OF THE SPIRIT (PART 2 OF 5)", "Joy."),
+		post("Galatians 5:22", "September 1, 2025", "FRUIT OF THE SPIRIT (PART 1 OF 5)", "Love."),
+		post("Galatians 5:22", "September 3, 2025", "PEACE THAT GUARDS", "Part 3 of our series on the Fruit of the Spirit."),
+		// Three consecutive readings through Romans, then one too late to belong
+		post("Romans 8:1-4", "August 4, 2025", "NO CONDEMNATION", "Free."),
+		post("Romans 8:28-30", "August 5, 2025", "ALL THINGS FOR GOOD", "Good."),
+		post("Romans 9:1-5", "August 6, 2025", "GREAT SORROW", "Sorrow."),
+		post("Romans 10:9-10", "August 20, 2025", "CONFESS AND BELIEVE", "Believe."),
+		// Readings through a book out of order are no series
+		post("John 3:16", "July 1, 2025", "FOR GOD SO LOVED", "Love."),
+		post("John 1:1", "July 2, 2025", "IN THE BEGINNING", "Word."),
+		post("John 2:1", "July 3, 2025", "WATER INTO WINE", "Wine."),
+	}
+	for _, msg := range posts {
+		devo := parser.ParseDevotional(msg)
+		if err := db.SaveDevotional(&devo); err != nil {
+			t.Fatalf("Failed to save devotional: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/series", nil)
+	w := httptest.NewRecorder()
+	handler.GetSeries(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var list struct {
+		Data []models.Series `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Data) != 2 {
+		t.Fatalf("Expected 2 series, got %+v", list.Data)
+	}
+	if s := list.Data[0]; s.ID != "fruit-of-the-spirit" || s.Kind != database.SeriesMarked || s.Count != 3 || s.Parts != 5 || s.Entries != nil {
+		t.Errorf("Expected the Fruit of the Spirit series first, without entries, got %+v", s)
+	}
+	if s := list.Data[1]; s.ID != "romans-2025-08-04" || s.Kind != database.SeriesBook || s.Count != 3 || s.EndDate != "August 6, 2025" {
+		t.Errorf("Expected the Romans run, got %+v", s)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/series/fruit-of-the-spirit", nil)
+	w = httptest.NewRecorder()
+	handler.GetSeriesByID(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var detail struct {
+		Data models.Series `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&detail); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	var titles []string
+	for _, e := range detail.Data.Entries {
+		titles = append(titles, fmt.Sprintf("%d %s", e.Part, e.Title))
+	}
+	want := "[1 FRUIT OF THE SPIRIT (PART 1 OF 5) 2 FRUIT OF THE SPIRIT (PART 2 OF 5) 3 PEACE THAT GUARDS]"
+	if fmt.Sprint(titles) != want {
+		t.Errorf("Expected entries %s, got %v", want, titles)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/series/unknown", nil)
+	w = httptest.NewRecorder()
+	handler.GetSeriesByID(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown series, got %d", w.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"lwnra-devo-api/database"
+)
+
+// StatsHandler handles statistics endpoints
+type StatsHandler struct {
+	db *database.DB
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(db *database.DB) *StatsHandler {
+	return &StatsHandler{
+		db: db,
+	}
+}
+
+// engagementPeriods maps the accepted ?period= values to how far back they reach
+var engagementPeriods = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+	"year":  365 * 24 * time.Hour,
+	"all":   0,
+}
+
+// GetTopEngagement handles GET /api/stats/engagement
+func (h *StatsHandler) GetTopEngagement(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "week"
+	}
+	window, ok := engagementPeriods[period]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "Invalid period. Use day, week, month, year or all", nil)
+		return
+	}
+
+	limit := 10 // default
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	var since time.Time
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+
+	top, err := h.db.GetTopEngagement(since, limit)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch engagement stats", err)
+		return
+	}
+
+	respondWithSuccess(w, "Engagement stats retrieved successfully", map[string]interface{}{
+		"period":      period,
+		"devotionals": top,
+	})
+}
+
+// GetEngagementHistory handles GET /api/stats/engagement/{date}
+func (h *StatsHandler) GetEngagementHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	date := strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, "/"), "/api/stats/engagement/")
+	if date == "" || strings.Contains(date, "/") {
+		respondWithError(w, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD", nil)
+		return
+	}
+
+	date = displayDate(date)
+	if _, err := h.db.GetDevotionalByDate(date); err != nil {
+		respondWithError(w, http.StatusNotFound, "Devotional not found for the specified date", err)
+		return
+	}
+
+	history, err := h.db.GetEngagementHistory(date)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch engagement history", err)
+		return
+	}
+
+	respondWithSuccess(w, "Engagement history retrieved successfully", map[string]interface{}{
+		"date":      date,
+		"snapshots": history,
+	})
+}
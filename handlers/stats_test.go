@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/models"
+)
+
+func TestGetTopEngagement(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	handler := NewStatsHandler(db)
+
+	now := time.Now().UTC()
+	posts := []models.Devotional{
+		{Date: "August 1, 2025", Reading: "Psalm 46:10", Title: "QUIET", Body: "Be still.", FBPostID: "1_1", PostedAt: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+		{Date: "August 2, 2025", Reading: "Psalm 46:10", Title: "LOUD", Body: "Be still.", FBPostID: "1_2", PostedAt: now.Add(-24 * time.Hour).Format(time.RFC3339)},
+		{Date: "June 1, 2025", Reading: "Psalm 46:10", Title: "OLD", Body: "Be still.", FBPostID: "1_3", PostedAt: now.Add(-60 * 24 * time.Hour).Format(time.RFC3339)},
+		// Held for review without a title, so kept out of public stats
+		{Date: "August 3, 2025", Reading: "Psalm 46:10", Body: "Be still.", FBPostID: "1_4", PostedAt: now.Add(-12 * time.Hour).Format(time.RFC3339)},
+	}
+	for i := range posts {
+		if err := db.SaveDevotional(&posts[i]); err != nil {
+			t.Fatalf("Failed to save devotional: %v", err)
+		}
+	}
+
+	refs, err := db.GetPostsSince(time.Time{})
+	if err != nil || len(refs) != 4 {
+		t.Fatalf("Expected 4 posts, got %d (%v)", len(refs), err)
+	}
+	counts := map[string]models.Engagement{
+		"1_1": {Reactions: 5, Comments: 1},
+		"1_2": {Reactions: 40, Comments: 7, Shares: 3},
+		"1_3": {Reactions: 100},
+		"1_4": {Reactions: 500},
+	}
+	for _, ref := range refs {
+		// The first snapshot is superseded by the second
+		db.SaveEngagementSnapshot(ref.DevotionalID, models.Engagement{}, now.Add(-time.Hour))
+		db.SaveEngagementSnapshot(ref.DevotionalID, counts[ref.PostID], now)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats/engagement?period=week", nil)
+	w := httptest.NewRecorder()
+	handler.GetTopEngagement(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data struct {
+			Devotionals []models.DevotionalEngagement `json:"devotionals"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	top := response.Data.Devotionals
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 devotionals in the last week, got %d", len(top))
+	}
+	if top[0].Title != "LOUD" || top[0].Total != 50 {
+		t.Errorf("Expected LOUD with total 50 first, got %s with %d", top[0].Title, top[0].Total)
+	}
+
+	// History is returned oldest first
+	req = httptest.NewRequest(http.MethodGet, "/api/stats/engagement/2025-08-02", nil)
+	w = httptest.NewRecorder()
+	handler.GetEngagementHistory(w, req)
+
+	var history struct {
+		Data struct {
+			Snapshots []models.EngagementSnapshot `json:"snapshots"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &history)
+
+	if len(history.Data.Snapshots) != 2 || history.Data.Snapshots[1].Reactions != 40 {
+		t.Errorf("Unexpected engagement history: %+v", history.Data.Snapshots)
+	}
+
+	// The devotional held for review has no public history
+	req = httptest.NewRequest(http.MethodGet, "/api/stats/engagement/2025-08-03", nil)
+	w = httptest.NewRecorder()
+	handler.GetEngagementHistory(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for a devotional held for review, got %d", w.Code)
+	}
+	if history, err := db.GetEngagementHistory("August 3, 2025"); err != nil || len(history) != 0 {
+		t.Errorf("Expected no engagement history for a devotional held for review, got %+v %v", history, err)
+	}
+}
@@ -5,18 +5,21 @@ import (
 	"net/http"
 	"time"
 
+	"lwnra-devo-api/facebook"
 	"lwnra-devo-api/scheduler"
 )
 
 // SystemHandler handles system-related endpoints
 type SystemHandler struct {
 	scheduler *scheduler.Scheduler
+	fbClient  *facebook.Client
 }
 
 // NewSystemHandler creates a new system handler
-func NewSystemHandler(sched *scheduler.Scheduler) *SystemHandler {
+func NewSystemHandler(sched *scheduler.Scheduler, fbClient *facebook.Client) *SystemHandler {
 	return &SystemHandler{
 		scheduler: sched,
+		fbClient:  fbClient,
 	}
 }
 
@@ -58,8 +61,9 @@ func (h *SystemHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		Success: true,
 		Message: "API is healthy",
 		Data: map[string]interface{}{
-			"timestamp": time.Now(),
-			"version":   "1.0.0",
+			"timestamp":           time.Now(),
+			"version":             "1.0.0",
+			"facebook_credential": h.fbClient.ActiveCredential(),
 		},
 	}
 
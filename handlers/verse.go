@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+)
+
+// verseCard is the verse to carry through the day, with the quote from the
+// devotional it belongs to
+type verseCard struct {
+	Date        string              `json:"date"`
+	Title       string              `json:"title"`
+	Reading     string              `json:"reading"`
+	Version     string              `json:"version"`
+	MemoryVerse *models.MemoryVerse `json:"memory_verse"`
+	PullQuote   string              `json:"pull_quote"`
+}
+
+// GetTodayVerse handles GET /api/devotionals/today/verse, the memory verse
+// and pull quote of today's devotional, by Philippine time
+func (h *DevotionalHandler) GetTodayVerse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	devo, err := h.db.GetDevotionalByDate(today())
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "No devotional for today", err)
+		return
+	}
+
+	// Devotionals stored before the highlights were picked get them now
+	if devo.MemoryVerse == nil && devo.PullQuote == "" {
+		derived := parser.Derive(*devo)
+		devo = &derived
+	}
+
+	respondWithSuccess(w, "Verse retrieved successfully", verseCard{
+		Date:        devo.Date,
+		Title:       devo.Title,
+		Reading:     devo.Reading,
+		Version:     devo.Version,
+		MemoryVerse: devo.MemoryVerse,
+		PullQuote:   devo.PullQuote,
+	})
+}
+
+// today returns today's date in the Philippines, where the devotionals are
+// posted, in the format they are stored with
+func today() string {
+	location, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		location = time.FixedZone("PHT", 8*60*60)
+	}
+	return time.Now().In(location).Format("January 2, 2006")
+}
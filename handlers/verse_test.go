@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
+)
+
+func TestGetTodayVerse(t *testing.T) {
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+	handler := NewDevotionalHandler(db, nil, storage.NewImageStore(t.TempDir()))
+
+	get := func() (int, verseCard) {
+		req := httptest.NewRequest(http.MethodGet, "/api/devotionals/today/verse", nil)
+		w := httptest.NewRecorder()
+		handler.GetTodayVerse(w, req)
+		var response struct {
+			Data verseCard `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+		return w.Code, response.Data
+	}
+
+	if code, _ := get(); code != http.StatusNotFound {
+		t.Fatalf("Expected status 404 without a devotional for today, got %d", code)
+	}
+
+	msg := "DAILY DEVOTIONAL\nRead Psalm 46:1-10\n" + today() + "\nPsalm 46:1-10 NIV\n" +
+		"1 God is our refuge and strength, an ever-present help in trouble.\n10 Be still, and know that I am God.\n" +
+		"REFLECTION QUESTIONS\nWhat keeps you busy?\nBE STILL\nBen Villanueva\n" +
+		"We rush from one task to the next (v. 1).\n\n> God is not waiting for you to slow down before He helps you.\n\n" +
+		"\"Be still, and know that I am God.\" (v. 10) He is God (v. 10).\nPRAYER\nAmen."
+	devo := parser.ParseDevotional(msg)
+	if err := db.SaveDevotional(&devo); err != nil {
+		t.Fatalf("Failed to save devotional: %v", err)
+	}
+
+	code, card := get()
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	want := &models.MemoryVerse{Reference: "Psalm 46:10", Text: "Be still, and know that I am God.", Citations: 2}
+	if card.Date != today() || card.Title != "BE STILL" || card.MemoryVerse == nil || *card.MemoryVerse != *want {
+		t.Errorf("Expected today's card with the memory verse %+v, got %+v", want, card)
+	}
+	if card.PullQuote != "God is not waiting for you to slow down before He helps you." {
+		t.Errorf("Expected the quote set apart as the pull quote, got %q", card.PullQuote)
+	}
+}
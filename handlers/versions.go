@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"net/http"
+
+	"lwnra-devo-api/scripture"
+)
+
+// GetVersions handles GET /api/versions, the catalogue of Bible versions
+// with the attribution to show alongside quoted passages
+func (h *DevotionalHandler) GetVersions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	respondWithSuccess(w, "Bible versions retrieved successfully", scripture.Versions())
+}
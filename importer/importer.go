@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"lwnra-devo-api/database"
+	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/parser"
+	"lwnra-devo-api/storage"
+)
+
+// ErrNotDevotional is returned when a post is not a daily devotional
+var ErrNotDevotional = errors.New("post is not a daily devotional")
+
+// Importer turns Facebook posts into stored devotionals
+type Importer struct {
+	db     *database.DB
+	fb     *facebook.Client
+	images *storage.ImageStore
+}
+
+// New creates a new importer. images may be nil to skip downloading post images.
+func New(db *database.DB, fb *facebook.Client, images *storage.ImageStore) *Importer {
+	return &Importer{
+		db:     db,
+		fb:     fb,
+		images: images,
+	}
+}
+
+// ImportPost parses a devotional post, saves it and stores its images. An
+// incomplete devotional is saved held for review. A post already stored is
+// left as it is, with the fields an admin overrode.
+func (im *Importer) ImportPost(post models.FBPost) (models.Devotional, error) {
+	devo, _ := parsePost(post)
+	if _, _, err := im.applyOverrides(&devo); err != nil {
+		return devo, err
+	}
+
+	if err := im.db.SaveDevotional(&devo); err != nil {
+		return devo, err
+	}
+
+	// A missing image should never fail the import of the text itself
+	if err := im.storeImages(post, devo); err != nil {
+		log.Printf("Failed to store images for devotional '%s': %v", devo.Title, err)
+	}
+
+	return devo, nil
+}
+
+// ResyncPost re-parses a single post and overwrites the stored devotional with
+// the result, keeping the fields an admin overrode. It returns the devotional
+// and warnings about missing and overridden fields.
+func (im *Importer) ResyncPost(post models.FBPost) (models.Devotional, []string, error) {
+	if !facebook.IsDevotionalPost(post.Message) {
+		return models.Devotional{}, nil, ErrNotDevotional
+	}
+
+	devo, warnings := parsePost(post)
+
+	id, overrides, err := im.applyOverrides(&devo)
+	if err != nil {
+		return devo, warnings, err
+	}
+	if len(overrides) > 0 {
+		var fields []string
+		for _, o := range overrides {
+			fields = append(fields, o.Field)
+		}
+		warnings = append(warnings, "overridden: "+strings.Join(fields, ", "))
+	}
+
+	if err := im.db.UpsertDevotional(&devo); err != nil {
+		return devo, warnings, err
+	}
+	if devo.Status == models.StatusPendingReview {
+		warnings = append(warnings, "held for review: "+strings.Join(devo.ReviewReasons, ", "))
+	}
+	for _, o := range overrides {
+		if err := im.db.SaveOverride(id, o); err != nil {
+			return devo, warnings, err
+		}
+	}
+
+	if err := im.storeImages(post, devo); err != nil {
+		warnings = append(warnings, "failed to store images: "+err.Error())
+	}
+
+	return devo, warnings, nil
+}
+
+// applyOverrides applies an admin's corrections of the stored devotional of
+// a post on top of a new parse of it. It returns the ID of the stored
+// devotional, 0 when there is none, and its overrides with the parsed values
+// refreshed from the new parse.
+func (im *Importer) applyOverrides(devo *models.Devotional) (int64, []models.FieldOverride, error) {
+	id, err := im.db.FindDevotionalID(devo.FBPostID, devo.Date, devo.Title)
+	if err != nil || id == 0 {
+		return 0, nil, err
+	}
+	overrides, err := im.db.GetOverrides(id)
+	if err != nil || len(overrides) == 0 {
+		return id, nil, err
+	}
+
+	for i, o := range overrides {
+		if overrides[i].Parsed, err = devo.FieldValue(o.Field); err != nil {
+			return id, nil, err
+		}
+	}
+	if err := devo.ApplyOverrides(overrides); err != nil {
+		return id, nil, err
+	}
+	*devo = parser.Derive(*devo)
+	return id, overrides, nil
+}
+
+// parsePost parses a post into a devotional, filling in the Facebook post
+// details and falling back to the post date when the text has none
+func parsePost(post models.FBPost) (models.Devotional, []string) {
+	result := parser.Parse(post.Message)
+	devo := result.Devotional
+	warnings := result.Warnings
+
+	devo.FBPostID = post.ID
+	if t, err := time.Parse("2006-01-02T15:04:05-0700", post.CreatedTime); err == nil {
+		devo.PostedAt = t.UTC().Format(time.RFC3339)
+	}
+
+	// Use post date if devotional date is empty
+	if devo.Date == "" {
+		postDate := extractAndFormatPostDate(post.CreatedTime)
+		if postDate != "" {
+			devo.Date = postDate
+			warnings = append(warnings, "date: using post creation date")
+		} else {
+			devo.Date = time.Now().Format("January 2, 2006")
+			warnings = append(warnings, "date: using today's date")
+		}
+	}
+
+	return devo, warnings
+}
+
+// storeImages downloads the post images into the image store and links them
+// to the devotional in place of its old ones. A post without images unlinks
+// them; when every download fails the old images are kept.
+func (im *Importer) storeImages(post models.FBPost, devo models.Devotional) error {
+	if im.images == nil {
+		return nil
+	}
+
+	urls := facebook.PostImageURLs(post)
+	if len(urls) == 0 {
+		return im.db.SaveDevotionalImages(devo.Date, devo.Title, nil)
+	}
+
+	var images []models.DevotionalImage
+	for i, u := range urls {
+		data, contentType, err := im.fb.DownloadImage(u)
+		if err != nil {
+			log.Printf("Skipping image %d of devotional '%s': %v", i, devo.Title, err)
+			continue
+		}
+
+		hash, err := im.images.Put(data)
+		if err != nil {
+			return fmt.Errorf("image %d: %w", i, err)
+		}
+
+		images = append(images, models.DevotionalImage{
+			Position:    i,
+			Hash:        hash,
+			ContentType: contentType,
+			SourceURL:   u,
+		})
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+	return im.db.SaveDevotionalImages(devo.Date, devo.Title, images)
+}
+
+// extractAndFormatPostDate converts Facebook's created_time to a readable date format
+func extractAndFormatPostDate(createdTime string) string {
+	t, err := time.Parse("2006-01-02T15:04:05-0700", createdTime)
+	if err != nil {
+		return ""
+	}
+	return t.Format("January 2, 2006")
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"lwnra-devo-api/database"
 	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/models"
 	"lwnra-devo-api/parser"
 )
 
@@ -62,8 +64,11 @@ func main() {
 		}
 
 		// Save to database
-		if err := db.SaveDevotional(devo); err != nil {
+		if err := db.SaveDevotional(&devo); err != nil {
 			fmt.Printf("Failed to save devotional '%s': %v\n", devo.Title, err)
+		} else if devo.Status == models.StatusPendingReview {
+			fmt.Printf("[%s] %s — Held for review (%s)\n", devo.Date, devo.Title, strings.Join(devo.ReviewReasons, ", "))
+			count++
 		} else {
 			fmt.Printf("[%s] %s — Saved to DB\n", devo.Date, devo.Title)
 			count++
@@ -0,0 +1,30 @@
+package models
+
+import "lwnra-devo-api/scripture"
+
+// Citation is a verse reference quoted inline in a devotional's body, such as
+// "(v. 3)" or "(Romans 8:28)"
+type Citation struct {
+	Text      string            `json:"text"`       // as written, e.g. "(v. 3)"
+	Start     int               `json:"start"`      // offset of the citation in the body, in UTF-16 code units
+	End       int               `json:"end"`        // offset just past the citation, in UTF-16 code units
+	Reference string            `json:"reference"`  // canonical reference, e.g. "Romans 8:3"
+	Book      string            `json:"book"`       // canonical book name
+	Ranges    []scripture.Range `json:"ranges"`     // cited verses
+	InReading bool              `json:"in_reading"` // whether the cited verses are part of the devotional's reading
+}
+
+// CrossReference is a passage cited outside of the devotionals' own readings,
+// with the devotionals that cite it
+type CrossReference struct {
+	Reference   string          `json:"reference"`
+	Book        string          `json:"book"`
+	Count       int             `json:"count"`
+	Devotionals []CitingSummary `json:"devotionals"`
+}
+
+// CitingSummary identifies a devotional that cites a passage
+type CitingSummary struct {
+	Date  string `json:"date"`
+	Title string `json:"title"`
+}
@@ -1,14 +1,94 @@
 package models
 
+import "lwnra-devo-api/scripture"
+
+// Publication states of a stored devotional
+const (
+	StatusPublished     = "published"
+	StatusPendingReview = "pending_review" // incomplete parse held back until an admin approves it
+)
+
 // Devotional represents a daily devotional entry
 type Devotional struct {
-	Date         string   `json:"date"`          // e.g. "August 2, 2025"
-	Reading      string   `json:"reading"`       // "Matthew 6:16-18"
-	Version      string   `json:"version"`       // Bible version like "NIV", "ESV", "NASB"
-	Passage      string   `json:"passage"`       // passage text
-	ReflectionQs []string `json:"reflection_qs"` // questions
-	Title        string   `json:"title"`         // devo title
-	Author       string   `json:"author"`        // author
-	Body         string   `json:"body"`          // main devo body
-	Prayer       string   `json:"prayer"`        // prayer
+	ID            int64                `json:"id,omitempty"`             // database ID, set on stored devotionals
+	Date          string               `json:"date"`                     // e.g. "August 2, 2025"
+	Reading       string               `json:"reading"`                  // "Matthew 6:16-18"
+	Version       string               `json:"version"`                  // Bible version like "NIV", "ESV", "NASB"
+	VersionInfo   *scripture.Version   `json:"version_info,omitempty"`   // catalogue entry of the version, nil if unknown
+	Passage       string               `json:"passage"`                  // passage text
+	Verses        []Verse              `json:"verses,omitempty"`         // passage split into verses
+	ReflectionQs  []string             `json:"reflection_qs"`            // questions
+	Title         string               `json:"title"`                    // devo title
+	Author        string               `json:"author"`                   // author
+	Body          string               `json:"body"`                     // main devo body
+	BodyBlocks    []BodyBlock          `json:"body_blocks,omitempty"`    // body split into paragraphs, quotes and list items
+	Citations     []Citation           `json:"citations,omitempty"`      // verse citations in the body
+	MemoryVerse   *MemoryVerse         `json:"memory_verse,omitempty"`   // verse of the passage to carry through the day
+	PullQuote     string               `json:"pull_quote,omitempty"`     // most quotable sentence of the body
+	Prayer        string               `json:"prayer"`                   // prayer
+	Footer        string               `json:"footer,omitempty"`         // sign-offs, links and hashtags closing the post
+	Hashtags      []string             `json:"hashtags,omitempty"`       // hashtags in the post, without "#"
+	URLs          []string             `json:"urls,omitempty"`           // links in the post
+	Mentions      []string             `json:"mentions,omitempty"`       // @mentions in the post, without "@"
+	FBPostID      string               `json:"fb_post_id,omitempty"`     // source Facebook post ID
+	PostedAt      string               `json:"posted_at,omitempty"`      // post creation time, RFC 3339 UTC
+	Template      string               `json:"template,omitempty"`       // name of the post template it was parsed with
+	Language      string               `json:"language,omitempty"`       // "en", "fil" or "taglish"
+	Styles        map[string]TextStyle `json:"styles,omitempty"`         // fields posted in styled bold or italic letters
+	Series        *SeriesMarker        `json:"series,omitempty"`         // place in a series, nil when the post has no marker
+	Status        string               `json:"status,omitempty"`         // StatusPublished or StatusPendingReview
+	ReviewReasons []string             `json:"review_reasons,omitempty"` // why the devotional is held for review
+}
+
+// ApplyQualityGate sets the status of a devotional: one missing the title,
+// reading or body its card shows is held for review instead of published
+func (d *Devotional) ApplyQualityGate() {
+	d.ReviewReasons = nil
+	for _, field := range []struct{ name, value string }{{"title", d.Title}, {"reading", d.Reading}, {"body", d.Body}} {
+		if field.value == "" {
+			d.ReviewReasons = append(d.ReviewReasons, "missing "+field.name)
+		}
+	}
+	d.Status = StatusPublished
+	if len(d.ReviewReasons) > 0 {
+		d.Status = StatusPendingReview
+	}
+}
+
+// TextStyle records the styling of text posted with Unicode bold or italic
+// letters, which are stored as plain letters
+type TextStyle struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+}
+
+// Verse is a single verse of a devotional's passage
+type Verse struct {
+	Chapter int    `json:"chapter,omitempty"` // 0 when the reading has no chapter
+	Number  int    `json:"number"`
+	Text    string `json:"text"` // lines of a wrapped verse are joined with a space
+}
+
+// MemoryVerse is the verse of a devotional's passage its body refers to most
+type MemoryVerse struct {
+	Reference string `json:"reference"` // e.g. "Hebrews 12:1"
+	Text      string `json:"text"`
+	Citations int    `json:"citations"` // citations of the verse in the body, 0 when none is cited
+}
+
+// BodyBlock is a paragraph, quote or list item of a devotional's body. Its
+// text keeps line breaks and the *bold* and _italic_ markup of the post.
+type BodyBlock struct {
+	Type      string `json:"type"`                // "paragraph", "quote", "scripture" or "list_item"
+	Text      string `json:"text"`                // without quote marks or list bullets
+	Reference string `json:"reference,omitempty"` // reference of a scripture quote, e.g. "Jeremiah 29:11"
+	TextStyle        // styling of the lines of the block
+}
+
+// DevotionalImage represents an image attached to a devotional's Facebook post
+type DevotionalImage struct {
+	Position    int    `json:"position"`     // order within the post, 0 is the main picture
+	Hash        string `json:"hash"`         // sha256 of the image content
+	ContentType string `json:"content_type"` // e.g. "image/jpeg"
+	SourceURL   string `json:"source_url"`   // original Facebook CDN URL
 }
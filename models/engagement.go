@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Engagement holds the reaction, comment and share counts of a Facebook post
+type Engagement struct {
+	Reactions int `json:"reactions"`
+	Comments  int `json:"comments"`
+	Shares    int `json:"shares"`
+}
+
+// Total returns the combined engagement count
+func (e Engagement) Total() int {
+	return e.Reactions + e.Comments + e.Shares
+}
+
+// EngagementSnapshot is the engagement of a post at a point in time
+type EngagementSnapshot struct {
+	Engagement
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// DevotionalEngagement is the latest engagement of a devotional
+type DevotionalEngagement struct {
+	Date     string `json:"date"`
+	Title    string `json:"title"`
+	Reading  string `json:"reading"`
+	FBPostID string `json:"fb_post_id"`
+	Engagement
+	Total      int       `json:"total"`
+	CapturedAt time.Time `json:"captured_at"`
+}
@@ -2,8 +2,11 @@ package models
 
 // FBPost represents a Facebook post from the API
 type FBPost struct {
-	Message     string `json:"message"`
-	CreatedTime string `json:"created_time"`
+	ID          string        `json:"id"`
+	Message     string        `json:"message"`
+	CreatedTime string        `json:"created_time"`
+	FullPicture string        `json:"full_picture"`
+	Attachments FBAttachments `json:"attachments"`
 }
 
 // FBPosts represents a collection of Facebook posts
@@ -11,9 +14,51 @@ type FBPosts struct {
 	Data []FBPost `json:"data"`
 }
 
+// FBAttachments represents the attachments edge of a Facebook post
+type FBAttachments struct {
+	Data []FBAttachment `json:"data"`
+}
+
+// FBAttachment represents a single post attachment (photo, album, share, ...)
+type FBAttachment struct {
+	Type           string        `json:"type"`
+	URL            string        `json:"url"`
+	Media          FBMedia       `json:"media"`
+	Subattachments FBAttachments `json:"subattachments"`
+}
+
+// FBMedia holds the media of an attachment
+type FBMedia struct {
+	Image FBImage `json:"image"`
+}
+
+// FBImage represents an image returned by the Graph API
+type FBImage struct {
+	Src    string `json:"src"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 // FBMeResponse represents the Facebook API response for the /me endpoint
 type FBMeResponse struct {
 	ID    string  `json:"id"`
 	Name  string  `json:"name"`
 	Posts FBPosts `json:"posts"`
 }
+
+// FBSummary holds the summary of a counted edge such as reactions or comments
+type FBSummary struct {
+	Summary struct {
+		TotalCount int `json:"total_count"`
+	} `json:"summary"`
+}
+
+// FBEngagementResponse represents the engagement fields of a post
+type FBEngagementResponse struct {
+	ID        string    `json:"id"`
+	Reactions FBSummary `json:"reactions"`
+	Comments  FBSummary `json:"comments"`
+	Shares    struct {
+		Count int `json:"count"`
+	} `json:"shares"`
+}
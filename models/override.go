@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// OverridableFields are the fields of a devotional an admin can override
+var OverridableFields = []string{"date", "reading", "version", "passage", "reflection_qs", "title", "author", "body", "prayer"}
+
+// FieldOverride is an admin's correction of a parsed field. It is applied on
+// top of the parser output whenever the devotional is parsed again.
+type FieldOverride struct {
+	Field     string          `json:"field"`
+	Parsed    json.RawMessage `json:"parsed"`     // value the parser gave, as JSON
+	Override  json.RawMessage `json:"override"`   // value shown instead, as JSON
+	UpdatedAt string          `json:"updated_at"` // RFC 3339 UTC
+}
+
+// fieldPointers returns the string fields that can be overridden by name
+func (d *Devotional) fieldPointers() map[string]*string {
+	return map[string]*string{
+		"date": &d.Date, "reading": &d.Reading, "version": &d.Version, "passage": &d.Passage,
+		"title": &d.Title, "author": &d.Author, "body": &d.Body, "prayer": &d.Prayer,
+	}
+}
+
+// FieldValue returns an overridable field as JSON
+func (d *Devotional) FieldValue(field string) (json.RawMessage, error) {
+	if field == "reflection_qs" {
+		qs := d.ReflectionQs
+		if qs == nil {
+			qs = []string{}
+		}
+		return json.Marshal(qs)
+	}
+	if p, ok := d.fieldPointers()[field]; ok {
+		return json.Marshal(*p)
+	}
+	return nil, fmt.Errorf("field cannot be overridden: %s", field)
+}
+
+// SetField sets an overridable field from JSON
+func (d *Devotional) SetField(field string, value json.RawMessage) error {
+	if field == "reflection_qs" {
+		var qs []string
+		if err := json.Unmarshal(value, &qs); err != nil {
+			return fmt.Errorf("invalid %s: %v", field, err)
+		}
+		d.ReflectionQs = nil
+		if len(qs) > 0 {
+			d.ReflectionQs = qs
+		}
+		return nil
+	}
+	p, ok := d.fieldPointers()[field]
+	if !ok {
+		return fmt.Errorf("field cannot be overridden: %s", field)
+	}
+	if err := json.Unmarshal(value, p); err != nil {
+		return fmt.Errorf("invalid %s: %v", field, err)
+	}
+	return nil
+}
+
+// ApplyOverrides sets the overridden fields of a devotional
+func (d *Devotional) ApplyOverrides(overrides []FieldOverride) error {
+	for _, o := range overrides {
+		if err := d.SetField(o.Field, o.Override); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+// SeriesMarker is a devotional's place in a series as written in its title or
+// body, e.g. "FRUIT OF THE SPIRIT (PART 2 OF 5)" or "Week 3: Patience"
+type SeriesMarker struct {
+	Text  string `json:"text"`            // the marker as written, e.g. "PART 2 OF 5"
+	Title string `json:"title,omitempty"` // series name, "" when the post doesn't name it
+	Part  int    `json:"part"`            // 0 when only the series is named
+	Of    int    `json:"of,omitempty"`    // announced number of parts, 0 when unknown
+}
+
+// Series is a group of devotionals that belong together: a series named in
+// the posts, or consecutive readings through one book
+type Series struct {
+	ID        string        `json:"id"`
+	Title     string        `json:"title"` // series name, or the book read through
+	Kind      string        `json:"kind"`  // "marked" or "book"
+	Book      string        `json:"book,omitempty"`
+	Parts     int           `json:"parts,omitempty"` // announced number of parts, 0 when unknown
+	Count     int           `json:"count"`
+	StartDate string        `json:"start_date"`
+	EndDate   string        `json:"end_date"`
+	Entries   []SeriesEntry `json:"entries,omitempty"` // in series order, left out of listings
+}
+
+// SeriesEntry is a devotional of a series
+type SeriesEntry struct {
+	Part    int    `json:"part,omitempty"`
+	Date    string `json:"date"`
+	Title   string `json:"title"`
+	Reading string `json:"reading"`
+}
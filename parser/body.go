@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+// Types of body blocks
+const (
+	BlockParagraph = "paragraph"
+	BlockQuote     = "quote"     // a quotation, or lines marked with ">"
+	BlockScripture = "scripture" // a quotation of a verse with its reference
+	BlockListItem  = "list_item"
+)
+
+var (
+	// listItemPattern matches a bulleted or numbered line: "- Pray", "• Pray",
+	// "1. Pray", "2) Pray"
+	listItemPattern = regexp.MustCompile(`^(?:[-*•‣◦▪]|\d{1,2}[.)])\s+(\S.*)$`)
+
+	// quotedPattern matches a quotation followed by its source:
+	// `"For I know the plans..." (Jeremiah 29:11)`, `"..." - Romans 8:28`
+	quotedPattern = regexp.MustCompile(`(?s)^"([^"]+)"[\s,.]*(?:\(([^()]*)\)|[-\x{2014}]\s*([^"]*))?[\s.]*$`)
+
+	// leadingReferencePattern matches a reference followed by the quoted verse:
+	// `Romans 8:28 - "And we know..."`, `Jeremiah 29:11: "For I know..."`
+	leadingReferencePattern = regexp.MustCompile(`(?s)^([^"\n]{3,40}?)\s*[:\-\x{2014}]\s*"([^"]+)"[\s.]*$`)
+)
+
+// BodyBlocks splits a devotional's body into blocks. Short references such
+// as "(v. 3)" are resolved against the reading.
+func BodyBlocks(body, reading string) []models.BodyBlock {
+	blocks, _ := splitBody(strings.Split(body, "\n"), nil, reading)
+	return blocks
+}
+
+// splitBody splits the lines of a body into blank line separated blocks.
+// Bulleted lines are list items of their own, and a block that is all quoted
+// is a quote, or a scripture quote when its source is a reference. styles,
+// when not nil, is the styling of each line. It also returns the index of
+// the block of each line, -1 for blank lines.
+func splitBody(lines []string, styles []models.TextStyle, reading string) ([]models.BodyBlock, []int) {
+	var readingRef *scripture.Reference
+	if refs, err := scripture.Parse(reading); err == nil && len(refs) == 1 {
+		readingRef = &refs[0]
+	}
+
+	var blocks []models.BodyBlock
+	lineBlock := make([]int, len(lines))
+	var text []string
+	var style models.TextStyle
+	flush := func() {
+		if len(text) > 0 {
+			block := classifyBlock(strings.Join(text, "\n"), readingRef)
+			block.TextStyle = style
+			blocks = append(blocks, block)
+		}
+		text, style = nil, models.TextStyle{}
+	}
+	for i, line := range lines {
+		var lineStyle models.TextStyle
+		if i < len(styles) {
+			lineStyle = styles[i]
+		}
+		line = strings.TrimSpace(line)
+		switch m := listItemPattern.FindStringSubmatch(line); {
+		case line == "":
+			flush()
+			lineBlock[i] = -1
+		case m != nil:
+			flush()
+			lineBlock[i] = len(blocks)
+			blocks = append(blocks, models.BodyBlock{Type: BlockListItem, Text: m[1], TextStyle: lineStyle})
+		case len(text) == 0 && len(blocks) > 0 && blocks[len(blocks)-1].Type == BlockListItem && strings.TrimSpace(lines[i-1]) != "":
+			// A wrapped list item continues on the next line
+			lineBlock[i] = len(blocks) - 1
+			last := &blocks[len(blocks)-1]
+			last.Text += "\n" + line
+			last.Bold = last.Bold || lineStyle.Bold
+			last.Italic = last.Italic || lineStyle.Italic
+		default:
+			// The block is added when it ends
+			lineBlock[i] = len(blocks)
+			text = append(text, line)
+			style.Bold = style.Bold || lineStyle.Bold
+			style.Italic = style.Italic || lineStyle.Italic
+		}
+	}
+	flush()
+	return blocks, lineBlock
+}
+
+// classifyBlock tells a paragraph from a quote or scripture quote
+func classifyBlock(text string, reading *scripture.Reference) models.BodyBlock {
+	blockType := BlockParagraph
+	if quoted, ok := stripQuoteMarkers(text); ok {
+		blockType, text = BlockQuote, quoted
+	}
+
+	if m := quotedPattern.FindStringSubmatch(text); m != nil {
+		source := strings.TrimSpace(m[2] + m[3])
+		if ref := blockReference(source, reading); ref != "" {
+			return models.BodyBlock{Type: BlockScripture, Text: strings.TrimSpace(m[1]), Reference: ref}
+		}
+		if source == "" {
+			return models.BodyBlock{Type: BlockQuote, Text: strings.TrimSpace(m[1])}
+		}
+	}
+	if m := leadingReferencePattern.FindStringSubmatch(text); m != nil {
+		if ref := blockReference(m[1], reading); ref != "" {
+			return models.BodyBlock{Type: BlockScripture, Text: strings.TrimSpace(m[2]), Reference: ref}
+		}
+	}
+	return models.BodyBlock{Type: blockType, Text: text}
+}
+
+// stripQuoteMarkers removes the ">" opening every line of a quote
+func stripQuoteMarkers(text string) (string, bool) {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, ">") {
+			return text, false
+		}
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(line, ">"))
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// blockReference returns the canonical reference a quote is attributed to, or
+// "" when the source is not one
+func blockReference(source string, reading *scripture.Reference) string {
+	if source == "" {
+		return ""
+	}
+	refs := resolveCitation(source, reading)
+	if len(refs) == 0 {
+		return ""
+	}
+	return scripture.Format(refs)
+}
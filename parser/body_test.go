@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+func TestBodyBlocks(t *testing.T) {
+	tests := []struct {
+		body string
+		want []models.BodyBlock
+	}{
+		{"One paragraph.\n\nTwo lines\nof a paragraph.", []models.BodyBlock{
+			{Type: BlockParagraph, Text: "One paragraph."},
+			{Type: BlockParagraph, Text: "Two lines\nof a paragraph."},
+		}},
+		{"We can:\n- Pray\n• Give thanks,\nalways\n\n1. Rest", []models.BodyBlock{
+			{Type: BlockParagraph, Text: "We can:"},
+			{Type: BlockListItem, Text: "Pray"},
+			{Type: BlockListItem, Text: "Give thanks,\nalways"},
+			{Type: BlockListItem, Text: "Rest"},
+		}},
+		{`"Be still, and know that I am God." (v. 10)`, []models.BodyBlock{
+			{Type: BlockScripture, Text: "Be still, and know that I am God.", Reference: "Psalm 46:10"},
+		}},
+		{`Romans 8:28 - "And we know that in all things God works for the good."`, []models.BodyBlock{
+			{Type: BlockScripture, Text: "And we know that in all things God works for the good.", Reference: "Romans 8:28"},
+		}},
+		{"> Faith is not a sprint.\n> It is a walk.", []models.BodyBlock{
+			{Type: BlockQuote, Text: "Faith is not a sprint.\nIt is a walk."},
+		}},
+		{`"Grace is free."`, []models.BodyBlock{{Type: BlockQuote, Text: "Grace is free."}}},
+		// Quotes within a paragraph, or of a person, are paragraphs
+		{`"Wait," she said. "Listen."`, []models.BodyBlock{{Type: BlockParagraph, Text: `"Wait," she said. "Listen."`}}},
+		{`"Grace is free." - a friend`, []models.BodyBlock{{Type: BlockParagraph, Text: `"Grace is free." - a friend`}}},
+	}
+
+	for _, tt := range tests {
+		if got := BodyBlocks(tt.body, "Psalm 46:1-11"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BodyBlocks(%q) = %+v, want %+v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestRenderBody(t *testing.T) {
+	body := []models.BodyBlock{
+		{Type: BlockParagraph, Text: "Run *with* patience, not 2 * 3 times <faster>."},
+		{Type: BlockScripture, Text: "Let us run.", Reference: "Hebrews 12:1"},
+		{Type: BlockListItem, Text: "Throw off what _hinders_"},
+		{Type: BlockListItem, Text: "Fix our eyes"},
+		{Type: BlockQuote, Text: "Not a sprint.\nA walk."},
+		{Type: BlockParagraph, Text: "You are not alone.", TextStyle: models.TextStyle{Bold: true}},
+	}
+
+	wantHTML := `<p>Run <strong>with</strong> patience, not 2 * 3 times &lt;faster&gt;.</p>
+<blockquote class="scripture"><p>Let us run.</p><cite>Hebrews 12:1</cite></blockquote>
+<ul>
+<li>Throw off what <em>hinders</em></li>
+<li>Fix our eyes</li>
+</ul>
+<blockquote><p>Not a sprint.<br>
+A walk.</p></blockquote>
+<p><strong>You are not alone.</strong></p>`
+	if got := RenderBodyHTML(body); got != wantHTML {
+		t.Errorf("RenderBodyHTML() =\n%s\nwant\n%s", got, wantHTML)
+	}
+
+	wantMarkdown := `Run **with** patience, not 2 \* 3 times \<faster>.
+
+> Let us run.
+>
+> — Hebrews 12:1
+
+- Throw off what _hinders_
+- Fix our eyes
+
+> Not a sprint.\
+> A walk.
+
+**You are not alone.**`
+	if got := RenderBodyMarkdown(body); got != wantMarkdown {
+		t.Errorf("RenderBodyMarkdown() =\n%s\nwant\n%s", got, wantMarkdown)
+	}
+}
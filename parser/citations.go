@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+var (
+	// parentheticalPattern matches a short parenthetical that may hold a citation
+	parentheticalPattern = regexp.MustCompile(`\(([^()\n]{1,60})\)`)
+	// shortCitationPattern matches verse-only citations like "v.1", "vv. 3-5" or "verses 10-11"
+	shortCitationPattern = regexp.MustCompile(`(?i)^(?:vv?|verses?)\.?\s*(\d+(?:\s*[-–,]\s*\d+)*)$`)
+	// chapterCitationPattern matches chapter and verse citations like "1:27" or "2:1-3"
+	chapterCitationPattern = regexp.MustCompile(`^\d+:\d+(?:\s*[-–,]\s*\d+(?::\d+)?)*$`)
+	// citationPrefixes are words that may introduce a citation, e.g. "(see Romans 8:28)"
+	citationPrefixes = []string{"see ", "cf. ", "cf ", "also ", "read "}
+)
+
+// extractCitations finds the verse citations in a devotional's body. Short
+// forms like "(v. 3)" and "(1:27)" are resolved against the reading.
+func extractCitations(body, reading string) []models.Citation {
+	var readingRef *scripture.Reference
+	if refs, err := scripture.Parse(reading); err == nil && len(refs) == 1 {
+		readingRef = &refs[0]
+	}
+
+	var citations []models.Citation
+	for _, loc := range parentheticalPattern.FindAllStringSubmatchIndex(body, -1) {
+		inner := strings.TrimSpace(body[loc[2]:loc[3]])
+		text := body[loc[0]:loc[1]]
+		start := utf16Len(body[:loc[0]])
+
+		// "(Ephesians 1:11; Jeremiah 29:11)" yields a citation per reference
+		for _, ref := range resolveCitation(inner, readingRef) {
+			citations = append(citations, models.Citation{
+				Text:      text,
+				Start:     start,
+				End:       start + utf16Len(text),
+				Reference: ref.String(),
+				Book:      ref.Book.Name,
+				Ranges:    ref.Ranges,
+				InReading: readingRef != nil && ref.Book == readingRef.Book && coversRanges(*readingRef, ref.Ranges),
+			})
+		}
+	}
+	return citations
+}
+
+// utf16Len counts the UTF-16 code units of s, the unit JavaScript, Swift
+// and Kotlin clients index strings in
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// resolveCitation turns the text inside a parenthetical into references
+func resolveCitation(text string, reading *scripture.Reference) []scripture.Reference {
+	for _, prefix := range citationPrefixes {
+		if rest, ok := cutPrefixFold(text, prefix); ok {
+			text = strings.TrimSpace(rest)
+			break
+		}
+	}
+
+	switch {
+	case shortCitationPattern.MatchString(text):
+		if reading == nil {
+			return nil
+		}
+		verses := shortCitationPattern.FindStringSubmatch(text)[1]
+		first, _ := strconv.Atoi(strings.FieldsFunc(verses, func(r rune) bool { return r < '0' || r > '9' })[0])
+		return parseCitation(reading.Book.Name + " " + strconv.Itoa(chapterOf(*reading, first)) + ":" + verses)
+
+	case chapterCitationPattern.MatchString(text):
+		if reading == nil {
+			return nil
+		}
+		return parseCitation(reading.Book.Name + " " + text)
+
+	case strings.ContainsAny(text, "0123456789"):
+		// Full references; each book must be followed by a chapter
+		return parseCitation(text)
+	}
+	return nil
+}
+
+// parseCitation parses cited references, or returns nil if s isn't one
+func parseCitation(s string) []scripture.Reference {
+	refs, err := scripture.Parse(s)
+	if err != nil {
+		return nil
+	}
+	return refs
+}
+
+// chapterOf returns the chapter of the reading a bare verse number refers to.
+// In readings that span chapters, like Genesis 1:26-2:3, it is the chapter
+// whose part of the reading contains the verse.
+func chapterOf(reading scripture.Reference, verse int) int {
+	for _, r := range reading.Ranges {
+		for ch := r.StartChapter; ch <= r.EndChapter; ch++ {
+			if r.Contains(ch, verse) {
+				return ch
+			}
+		}
+	}
+	return reading.Ranges[0].StartChapter
+}
+
+// coversRanges reports whether every verse range lies within the reading
+func coversRanges(reading scripture.Reference, ranges []scripture.Range) bool {
+	for _, r := range ranges {
+		if !reading.Contains(r.StartChapter, r.StartVerse) || !reading.Contains(r.EndChapter, r.EndVerse) {
+			return false
+		}
+	}
+	return true
+}
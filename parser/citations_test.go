@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func TestExtractCitations(t *testing.T) {
+	body := "God’s goal 🙏 is our good (v. 28), as Paul says elsewhere (see Ephesians 1:11).\n" +
+		"He restores my soul (life) and the creation rests (2:2; vv. 1-3 are the point) (vv. 29-30)."
+
+	citations := extractCitations(body, "Romans 8:28-30")
+	want := []struct {
+		text      string
+		reference string
+		inReading bool
+	}{
+		{"(v. 28)", "Romans 8:28", true},
+		{"(see Ephesians 1:11)", "Ephesians 1:11", false},
+		{"(vv. 29-30)", "Romans 8:29-30", true},
+	}
+
+	if len(citations) != len(want) {
+		t.Fatalf("Expected %d citations, got %+v", len(want), citations)
+	}
+	// Offsets count UTF-16 code units, two for the emoji
+	units := utf16.Encode([]rune(body))
+	for i, w := range want {
+		c := citations[i]
+		if c.Text != w.text || c.Reference != w.reference || c.InReading != w.inReading {
+			t.Errorf("Citation %d: expected %s %s %v, got %+v", i, w.text, w.reference, w.inReading, c)
+		}
+		if got := string(utf16.Decode(units[c.Start:c.End])); got != c.Text {
+			t.Errorf("Citation %d: offsets %d-%d point at %q", i, c.Start, c.End, got)
+		}
+	}
+}
+
+func TestExtractCitationsAcrossChapters(t *testing.T) {
+	citations := extractCitations("Made in His image (v. 27), then rest (v. 2) and more (Genesis 3:1).", "Genesis 1:26-2:3")
+
+	want := []string{"Genesis 1:27", "Genesis 2:2", "Genesis 3:1"}
+	if len(citations) != len(want) {
+		t.Fatalf("Expected %d citations, got %+v", len(want), citations)
+	}
+	for i, ref := range want {
+		if citations[i].Reference != ref {
+			t.Errorf("Expected %s, got %s", ref, citations[i].Reference)
+		}
+	}
+	if !citations[1].InReading || citations[2].InReading {
+		t.Errorf("Unexpected in_reading flags: %+v", citations)
+	}
+}
+
+func TestExtractCitationsWithoutReading(t *testing.T) {
+	citations := extractCitations("See (v. 3) and (John 3:16).", "")
+	if len(citations) != 1 || citations[0].Reference != "John 3:16" || citations[0].InReading {
+		t.Errorf("Expected only the full reference, got %+v", citations)
+	}
+}
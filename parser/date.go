@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// displayDateLayout is the format devotional dates are stored and shown in
+const displayDateLayout = "January 2, 2006"
+
+// months maps lower-case English and Filipino month names and abbreviations
+// to their month
+var months = map[string]time.Month{}
+
+// weekdays maps lower-case English and Filipino weekday names and
+// abbreviations to their weekday
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for name, names := range map[time.Month][]string{
+		time.January:   {"jan", "enero", "ene"},
+		time.February:  {"feb", "pebrero", "febrero", "peb"},
+		time.March:     {"mar", "marso"},
+		time.April:     {"apr", "abril", "abr"},
+		time.May:       {"mayo"},
+		time.June:      {"jun", "hunyo", "hun"},
+		time.July:      {"jul", "hulyo", "hul"},
+		time.August:    {"aug", "agosto", "ago"},
+		time.September: {"sep", "sept", "setyembre", "septiyembre", "set"},
+		time.October:   {"oct", "oktubre", "okt"},
+		time.November:  {"nov", "nobyembre", "nob"},
+		time.December:  {"dec", "disyembre", "dis"},
+	} {
+		months[strings.ToLower(name.String())] = name
+		for _, n := range names {
+			months[n] = name
+		}
+	}
+	for day, names := range map[time.Weekday][]string{
+		time.Sunday:    {"sun", "linggo"},
+		time.Monday:    {"mon", "lunes"},
+		time.Tuesday:   {"tue", "tues", "martes"},
+		time.Wednesday: {"wed", "miyerkules", "miyerkoles"},
+		time.Thursday:  {"thu", "thur", "thurs", "huwebes"},
+		time.Friday:    {"fri", "biyernes"},
+		time.Saturday:  {"sat", "sabado"},
+	} {
+		weekdays[strings.ToLower(day.String())] = day
+		for _, n := range names {
+			weekdays[n] = day
+		}
+	}
+}
+
+// datePattern matches a whole date: an optional weekday followed by
+// "August 2, 2025", "Aug. 2nd 2025", "2 August 2025", "ika-2 ng Agosto, 2025",
+// "08/02/2025" (month first) or "2025-08-02"
+var datePattern = regexp.MustCompile(`(?i)^(?:([a-z]+)\.?,?\s+)?(?:` +
+	`([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?\s*(\d{4})` +
+	`|(?:ika-?\s*)?(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+|ng\s+)?([a-z]+)\.?,?\s+(\d{4})` +
+	`|(\d{1,2})[/.-](\d{1,2})[/.-](\d{4})` +
+	`|(\d{4})-(\d{1,2})-(\d{1,2}))$`)
+
+// bracedPattern finds the {...} groups of a line
+var bracedPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Date is a date recognized in a post
+type Date struct {
+	Display string    // e.g. "August 2, 2025"
+	Time    time.Time // midnight UTC of the day
+	Weekday string    // the weekday as written, "" when there was none
+}
+
+// WeekdayMatches reports whether the written weekday, if any, is the
+// weekday of the date
+func (d Date) WeekdayMatches() bool {
+	return d.Weekday == "" || weekdays[strings.ToLower(d.Weekday)] == d.Time.Weekday()
+}
+
+// weekdayWarning describes a weekday that doesn't match the date, or is ""
+func (d Date) weekdayWarning() string {
+	if d.WeekdayMatches() {
+		return ""
+	}
+	return fmt.Sprintf("weekday %s does not match %s, a %s", d.Weekday, d.Display, d.Time.Weekday())
+}
+
+// ParseDate recognizes a date written in any of the forms posts use, with an
+// optional weekday prefix, English or Filipino month names and
+// abbreviations, day-first order or numeric month-first form
+func ParseDate(s string) (Date, bool) {
+	m := datePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Date{}, false
+	}
+
+	weekday := m[1]
+	if _, ok := weekdays[strings.ToLower(weekday)]; weekday != "" && !ok {
+		return Date{}, false
+	}
+
+	var month time.Month
+	var day, year int
+	switch {
+	case m[2] != "":
+		month, day, year = months[strings.ToLower(m[2])], atoi(m[3]), atoi(m[4])
+	case m[6] != "":
+		month, day, year = months[strings.ToLower(m[6])], atoi(m[5]), atoi(m[7])
+	case m[8] != "":
+		month, day, year = time.Month(atoi(m[8])), atoi(m[9]), atoi(m[10])
+		if month > 12 && day <= 12 {
+			// 13/08/2025 can only be day first
+			month, day = time.Month(day), int(month)
+		}
+	default:
+		year, month, day = atoi(m[11]), time.Month(atoi(m[12])), atoi(m[13])
+	}
+
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if month < time.January || month > time.December || t.Day() != day || t.Month() != month {
+		return Date{}, false
+	}
+	return Date{Display: t.Format(displayDateLayout), Time: t, Weekday: weekday}, true
+}
+
+// invalidDate reports whether s is written like a date but is not a real
+// one, e.g. "February 30, 2025"
+func invalidDate(s string) bool {
+	m := datePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return false
+	}
+	if _, ok := ParseDate(s); ok {
+		return false
+	}
+	for _, name := range []string{m[2], m[6]} {
+		if name != "" {
+			_, ok := months[strings.ToLower(name)]
+			return ok
+		}
+	}
+	return true
+}
+
+// maxDateLength is longer than any date line, so longer lines are not tried
+const maxDateLength = 48
+
+// extractDate returns the date text of a date line: a braced date anywhere
+// in it, or the whole line
+func extractDate(line string) string {
+	if !strings.ContainsAny(line, "0123456789") {
+		return ""
+	}
+	for _, m := range bracedPattern.FindAllStringSubmatch(line, -1) {
+		if _, ok := ParseDate(m[1]); ok {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	if len(line) > maxDateLength {
+		return ""
+	}
+	if _, ok := ParseDate(line); ok {
+		return strings.TrimSpace(line)
+	}
+	return ""
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
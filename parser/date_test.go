@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		weekday bool // whether the weekday matches
+	}{
+		{"August 2, 2025", "August 2, 2025", true},
+		{"August 2,2025", "August 2, 2025", true},
+		{"Aug 2, 2025", "August 2, 2025", true},
+		{"Aug. 2nd 2025", "August 2, 2025", true},
+		{"Sept 14, 2025", "September 14, 2025", true},
+		{"Saturday, August 2, 2025", "August 2, 2025", true},
+		{"Sat, Aug 2, 2025", "August 2, 2025", true},
+		{"Sunday, August 2, 2025", "August 2, 2025", false},
+		{"2 August 2025", "August 2, 2025", true},
+		{"2nd of August, 2025", "August 2, 2025", true},
+		{"08/02/2025", "August 2, 2025", true},
+		{"8-2-2025", "August 2, 2025", true},
+		{"13/08/2025", "August 13, 2025", true},
+		{"2025-08-02", "August 2, 2025", true},
+		{"Agosto 12, 2025", "August 12, 2025", true},
+		{"Setyembre 3,2025", "September 3, 2025", true},
+		{"Sabado, ika-2 ng Agosto, 2025", "August 2, 2025", true},
+		{"Disyembre 25, 2025", "December 25, 2025", true},
+	}
+
+	for _, tt := range tests {
+		d, ok := ParseDate(tt.in)
+		if !ok {
+			t.Errorf("ParseDate(%q) failed, want %s", tt.in, tt.want)
+			continue
+		}
+		if d.Display != tt.want {
+			t.Errorf("ParseDate(%q) = %s, want %s", tt.in, d.Display, tt.want)
+		}
+		if d.WeekdayMatches() != tt.weekday {
+			t.Errorf("ParseDate(%q) weekday matches = %v, want %v", tt.in, d.WeekdayMatches(), tt.weekday)
+		}
+	}
+
+	if d, _ := ParseDate("Saturday, August 2, 2025"); !d.Time.Equal(time.Date(2025, time.August, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected midnight UTC on August 2, 2025, got %v", d.Time)
+	}
+
+	for _, s := range []string{"", "August 32, 2025", "February 29, 2025", "Someday, August 2, 2025", "13/13/2025", "Happy birthday 2025", "2 Corinthians 2025"} {
+		if d, ok := ParseDate(s); ok {
+			t.Errorf("ParseDate(%q) = %s, want no date", s, d.Display)
+		}
+	}
+}
+
+func TestWeekdayMismatchWarning(t *testing.T) {
+	msg := "DAILY DEVOTIONAL\nRead Micah 6:8\nSunday, August 2, 2025\nMicah 6:8 NIV\n8 He has shown you, O mortal, what is good."
+
+	result := Parse(msg)
+	if result.Devotional.Date != "August 2, 2025" {
+		t.Fatalf("Expected August 2, 2025, got %q", result.Devotional.Date)
+	}
+	if result.DateTime == nil || result.DateTime.Weekday() != time.Saturday {
+		t.Errorf("Expected the date as a time, got %v", result.DateTime)
+	}
+	date := result.Fields[FieldDate]
+	if len(date.Warnings) != 1 || date.Warnings[0] != "weekday Sunday does not match August 2, 2025, a Saturday" {
+		t.Errorf("Expected a weekday warning, got %v", date.Warnings)
+	}
+}
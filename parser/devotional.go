@@ -5,564 +5,629 @@ import (
 	"strings"
 
 	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
 )
 
-// ParseDevotional parses a Facebook post message into a Devotional struct
-func ParseDevotional(msg string) models.Devotional {
-	var devo models.Devotional
-	lines := strings.Split(msg, "\n")
-
-	// Find values between curly braces {} or after known section headers
-	devo.Reading = findReadingAfterPrefix(lines, "Read")
-	devo.Date = normalizeDate(findBraceThatLooksLikeDate(lines))
-	devo.Version = findBibleVersion(lines, devo.Reading)
-	devo.Passage = grabPassageAfterVersion(lines, devo.Reading, devo.Version)
-	devo.ReflectionQs = getReflectionQuestions(lines)
-	devo.Title = findActualTitle(lines)
-	devo.Author = findAuthorAfterTitle(lines, devo.Title)
-	devo.Body = grabDevotionalBody(lines, devo.Title, devo.Author)
-	devo.Prayer = grabPrayerSection(lines)
-
-	// Clean up
-	devo.Passage = strings.Trim(devo.Passage, "{} \n")
-	devo.Body = strings.Trim(devo.Body, "{} \n")
-	devo.Prayer = strings.Trim(devo.Prayer, "{} \n")
+// Patterns used while scanning a post, compiled once
+var (
+	versionPattern     = regexp.MustCompile(`^\(?([A-Za-z][A-Za-z0-9]*(?:-[A-Za-z0-9]+)?)\)?(?:\s|$)`)
+	versionCodePattern = regexp.MustCompile(`^[A-Z]{2,6}[0-9]*(?:-[A-Za-z0-9]+)?$`)
+	verseStartPattern  = regexp.MustCompile(`^\d+\s+`)
+	directRefPattern   = regexp.MustCompile(`^([1-3]?\s*[A-Za-z]+(?:\s+[A-Za-z]+)*)\s+(\d+:\d+(?:-\d+)?)\s*([A-Z]{2,4})?`)
+)
 
-	return devo
+// lineKind classifies a line of a post for the section state machine
+type lineKind int
+
+const (
+	kindBlank     lineKind = iota
+	kindText               // any other line
+	kindRead               // "Read ..." line introducing the reading reference
+	kindDate               // a date line, braced or not
+	kindVerse              // a line starting with a verse number
+	kindQuestions          // REFLECTION QUESTIONS header
+	kindPrayer             // PRAYER header
+	kindCaps               // an all-caps line, likely the title
+)
+
+// token is a classified line of a post
+type token struct {
+	index int    // 0-based line index
+	raw   string // the line as posted
+	text  string // the line without surrounding whitespace
+	kind  lineKind
 }
 
-func findBraceAfterPrefix(lines []string, prefix string) string {
-	for i, l := range lines {
-		if strings.HasPrefix(l, prefix) && strings.Contains(l, "{") && strings.Contains(l, "}") {
-			return extractCurly(l)
-		}
-		// Sometimes it's on the next line
-		if strings.HasPrefix(l, prefix) && i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "{") {
-			return extractCurly(lines[i+1])
-		}
-	}
-	return ""
+// scannedLine is what classify needs to know about a line whatever the
+// template. Lines are scanned once per post and shared by every template
+// tried on it, so the date and verse patterns run once per line.
+type scannedLine struct {
+	text  string // the line without surrounding whitespace
+	upper string // text in upper case
+	date  Date   // the date on the line, zero when there is none
+	verse bool   // the line starts with a verse number
 }
 
-// findReadingAfterPrefix finds the reading reference after "Read" line, handling both braced and unbraced formats
-func findReadingAfterPrefix(lines []string, prefix string) string {
-	for i, l := range lines {
-		// Check if line starts with the prefix (e.g., "Read")
-		if strings.HasPrefix(l, prefix) {
-			// First, check if reading is on the same line (e.g., "Read Matthew 6:16-18")
-			if len(l) > len(prefix) {
-				reading := strings.TrimSpace(l[len(prefix):])
-				// Check if it contains braces
-				if strings.Contains(reading, "{") && strings.Contains(reading, "}") {
-					return extractCurly(reading)
-				}
-				// If no braces, return the text after "Read"
-				if reading != "" {
-					return reading
-				}
-			}
+// scanLines scans the normalized lines of a post
+func scanLines(lines []string) []scannedLine {
+	scanned := make([]scannedLine, len(lines))
+	for i, raw := range lines {
+		text := strings.TrimSpace(raw)
+		l := scannedLine{text: text, upper: strings.ToUpper(text)}
+		if date := extractDate(text); date != "" {
+			l.date, _ = ParseDate(date)
+		}
+		l.verse = verseStartPattern.MatchString(text)
+		scanned[i] = l
+	}
+	return scanned
+}
 
-			// If not on same line, check the next line
-			if i+1 < len(lines) {
-				nextLine := strings.TrimSpace(lines[i+1])
-				if nextLine != "" {
-					// Check if it's a braced format
-					if strings.HasPrefix(nextLine, "{") && strings.HasSuffix(nextLine, "}") {
-						return extractCurly(nextLine)
-					}
-					// Check if it looks like a Bible reference (before the date)
-					if !isDateLine(nextLine) {
-						return nextLine
-					}
-				}
-			}
-		}
+// classify turns a line into a token using the template's headers
+func (m *machine) classify(index int) token {
+	raw, l := m.lines[index], m.scanned[index]
+	t := token{index: index, raw: raw, text: l.text}
+	switch {
+	case t.text == "":
+		t.kind = kindBlank
+	case m.isReadLine(raw):
+		t.kind = kindRead
+	case m.template.isQuestionsHeader(l.upper):
+		t.kind = kindQuestions
+	case m.template.isPrayerHeader(t.text):
+		t.kind = kindPrayer
+	case l.date.Display != "":
+		t.kind = kindDate
+	case l.verse:
+		t.kind = kindVerse
+	case m.template.isTitle(t.text, l.upper):
+		t.kind = kindCaps
+	default:
+		t.kind = kindText
 	}
-	return ""
+	return t
 }
 
-func findBraceThatLooksLikeDate(lines []string) string {
-	// Look for date in format: Month Day, Year (with or without curly braces)
-	reBraced := regexp.MustCompile(`\{(January|February|March|April|May|June|July|August|September|October|November|December) [0-9]{1,2}, [0-9]{4}\}`)
-	reUnbraced := regexp.MustCompile(`^(January|February|March|April|May|June|July|August|September|October|November|December) [0-9]{1,2}, [0-9]{4}$`)
+// section is a state of the section state machine. Posts move through the
+// sections of the fields in the order of their template.
+type section int
+
+const (
+	sectionHeader      section = iota // "DAILY DEVOTIONAL" banner before the "Read" line
+	sectionReading                    // bare "Read" line seen, reference expected on the next line
+	sectionDate                       // date expected right after the reading
+	sectionVersion                    // "<reference> <VERSION>" line expected
+	sectionPassageAt                  // version line seen, waiting for the first verse
+	sectionPassage                    // verses, up to the reflection questions
+	sectionQuestionsAt                // waiting for the REFLECTION QUESTIONS header
+	sectionQuestions                  // reflection questions, up to the all-caps title
+	sectionTitle                      // waiting for the all-caps title
+	sectionAuthor                     // title seen, author expected on the next line
+	sectionBody                       // devotional body, up to the PRAYER header
+	sectionPrayer                     // prayer, to the end of the post
+	sectionEnd                        // past the template's last field
+)
 
-	// First, try to find it after "Read" line for better accuracy
-	foundRead := false
-	for _, l := range lines {
-		l = strings.TrimSpace(l)
-		if !foundRead {
-			if strings.HasPrefix(l, "Read") {
-				foundRead = true
-			}
-			continue
-		}
+// fieldSections maps each field to the section the machine is in while
+// expecting it
+var fieldSections = map[string]section{
+	FieldReading:      sectionHeader,
+	FieldDate:         sectionDate,
+	FieldVersion:      sectionVersion,
+	FieldPassage:      sectionPassageAt,
+	FieldReflectionQs: sectionQuestionsAt,
+	FieldTitle:        sectionTitle,
+	FieldAuthor:       sectionAuthor,
+	FieldBody:         sectionBody,
+	FieldPrayer:       sectionPrayer,
+}
 
-		// After "Read" line, look for a valid date line
-		// First try braced format
-		m := reBraced.FindStringSubmatch(l)
-		if len(m) > 0 {
-			return strings.Trim(m[0], "{}")
-		}
+// sectionFields maps each section to the field it reads or waits for
+var sectionFields = map[section]string{
+	sectionHeader: FieldReading, sectionReading: FieldReading, sectionDate: FieldDate, sectionVersion: FieldVersion,
+	sectionPassageAt: FieldPassage, sectionPassage: FieldPassage, sectionQuestionsAt: FieldReflectionQs, sectionQuestions: FieldReflectionQs,
+	sectionTitle: FieldTitle, sectionAuthor: FieldAuthor, sectionBody: FieldBody, sectionPrayer: FieldPrayer,
+}
 
-		// Then try unbraced format (like "August 2, 2025")
-		m = reUnbraced.FindStringSubmatch(l)
-		if len(m) > 0 {
-			return m[0]
-		}
+// lineRange is a half-open range of 0-based line indices; start is -1 when unset
+type lineRange struct {
+	start, end int
+}
 
-		// Stop searching if we hit a non-empty line that's not a date after "Read"
-		if foundRead && l != "" && !isDateLine(l) {
-			break
-		}
+func (r lineRange) found() bool {
+	return r.start >= 0
+}
+
+// span converts the range to a 1-based inclusive Span, or nil if unset
+func (r lineRange) span() *Span {
+	if !r.found() {
+		return nil
 	}
+	return &Span{StartLine: r.start + 1, EndLine: r.end}
+}
 
-	// If not found after "Read", search entire content for any date pattern
-	for _, l := range lines {
-		l = strings.TrimSpace(l)
+// machine is the single-pass section state machine. It records where every
+// field was found so Parse can report source spans without rescanning.
+type machine struct {
+	template *Template
+	lines    []string
+	scanned  []scannedLine
+	styles   []models.TextStyle // styling of each line before normalization
+	section  section
+	devo     models.Devotional
+
+	reading, version, date, firstDate lineRange
+	passage, questions, title, author lineRange
+	body, prayer, footer              lineRange
+	fallbackTitle                     lineRange
+	questionsHeader, prayerHeader     lineRange // the REFLECTION QUESTIONS and PRAYER header lines
+
+	dateAfterRead     bool // the date directly follows the reading
+	dateValue         Date // the recognized date, zero when none was found
+	titleFromFallback bool // no title after the questions, first all-caps line used
+	firstDateValue    Date // first date anywhere, used when none follows the reading
+	truncated         bool // the message was cut down to the size limits
+}
 
-		// Try braced format first
-		m := reBraced.FindStringSubmatch(l)
-		if len(m) > 0 {
-			return strings.Trim(m[0], "{}")
-		}
+func newRange() lineRange {
+	return lineRange{start: -1, end: -1}
+}
 
-		// Then try unbraced format
-		m = reUnbraced.FindStringSubmatch(l)
-		if len(m) > 0 {
-			return m[0]
-		}
+// run parses the normalized lines of a post with a template in a single pass
+func run(lines []string, scanned []scannedLine, styles []models.TextStyle, template *Template) *machine {
+	m := &machine{template: template, lines: lines, scanned: scanned, styles: styles, section: fieldSections[template.Order[0]]}
+	for _, r := range []*lineRange{&m.reading, &m.version, &m.date, &m.firstDate, &m.passage, &m.questions, &m.title, &m.author, &m.body, &m.prayer, &m.footer, &m.fallbackTitle, &m.questionsHeader, &m.prayerHeader} {
+		*r = newRange()
 	}
 
-	return ""
-}
+	for i := range m.lines {
+		m.feed(m.classify(i))
+	}
 
-func grabSection(lines []string, start string, until string) string {
-	startIdx := -1
-	untilIdx := len(lines)
-	for i, l := range lines {
-		// For finding the start section
-		if strings.Contains(strings.ToUpper(l), strings.ToUpper(start)) && startIdx == -1 {
-			startIdx = i + 1 // exclude header line
+	// No title after the reflection questions: fall back to the first all-caps
+	// line and re-run the title, author, body and prayer sections from there
+	if !m.title.found() && m.fallbackTitle.found() && m.position() <= template.pos[FieldTitle] {
+		m.titleFromFallback = true
+		if m.passage.start < m.fallbackTitle.start && m.passage.end > m.fallbackTitle.start {
+			m.passage.end = m.fallbackTitle.start
 		}
-		// For finding the until section - be strict about "PRAYER" being all caps
-		if until != "" && startIdx != -1 && i > startIdx {
-			if until == "PRAYER" {
-				// Strict matching: must contain exactly "PRAYER" in all caps
-				if strings.Contains(l, "PRAYER") {
-					untilIdx = i
-					break
-				}
-			} else {
-				// For other sections, use case-insensitive matching
-				if strings.Contains(strings.ToUpper(l), strings.ToUpper(until)) {
-					untilIdx = i
-					break
-				}
-			}
+		m.section = sectionTitle
+		m.setTitle(m.classify(m.fallbackTitle.start))
+		for i := m.fallbackTitle.start + 1; i < len(m.lines); i++ {
+			m.feed(m.classify(i))
 		}
 	}
-	if startIdx >= 0 && startIdx < untilIdx {
-		return strings.TrimSpace(strings.Join(lines[startIdx:untilIdx], "\n"))
-	}
-	return ""
+
+	m.finish()
+	m.devo.Template = template.Name
+	return m
 }
 
-func getLinesBetween(lines []string, marker string, stopAt string) []string {
-	var found bool
-	var out []string
-	for i, l := range lines {
-		if found {
-			if stopAt != "" && strings.Contains(strings.ToUpper(l), strings.ToUpper(stopAt)) {
-				break
-			}
-			if strings.TrimSpace(l) != "" {
-				out = append(out, strings.TrimSpace(l))
-			}
-		}
-		if strings.Contains(strings.ToUpper(l), strings.ToUpper(marker)) {
-			found = true
-		}
-		// end if hit all-caps line that is probably title
-		if found && i > 0 && isLikelyTitle(l) {
-			break
-		}
+// next returns the section expecting the field the template has after the
+// given one
+func (m *machine) next(field string) section {
+	if i := m.template.pos[field] + 1; i < len(m.template.Order) {
+		return fieldSections[m.template.Order[i]]
 	}
-	return out
+	return sectionEnd
 }
 
-func findFirstBracketSection(lines []string, mustContain, mustContain2 string) string {
-	for _, l := range lines {
-		if strings.Contains(strings.ToUpper(l), strings.ToUpper(mustContain)) && strings.Contains(strings.ToUpper(l), strings.ToUpper(mustContain2)) {
-			return strings.Trim(l, "{} ")
-		}
-	}
-	for _, l := range lines {
-		if isLikelyTitle(l) {
-			return strings.Trim(l, "{} ")
-		}
+// position returns the place in the template's order of the field the
+// machine is reading
+func (m *machine) position() int {
+	if m.section == sectionEnd {
+		return len(m.template.Order)
 	}
-	return ""
+	return m.template.pos[sectionFields[m.section]]
 }
 
-func isLikelyTitle(line string) bool {
-	line = strings.TrimSpace(line)
-	return len(line) > 0 && strings.ToUpper(line) == line && len(line) > 5 && !strings.HasPrefix(line, "PRAYER") && !strings.Contains(line, "REFLECTION")
+// ahead reports whether the template has the field after the one the
+// machine is reading
+func (m *machine) ahead(field string) bool {
+	pos, ok := m.template.pos[field]
+	return ok && pos > m.position()
 }
 
-// Try to find author, assuming it's the line after title
-func findAfterTitle(lines []string, title string) string {
-	for i, l := range lines {
-		if strings.Trim(l, "{} ") == title && i+1 < len(lines) && len(strings.TrimSpace(lines[i+1])) > 0 {
-			return strings.Trim(lines[i+1], "{} ")
-		}
-	}
-	return ""
+// isReadLine reports whether the line introduces the reading reference
+func (m *machine) isReadLine(raw string) bool {
+	_, ok := m.template.readingPrefix(raw)
+	return ok
 }
 
-// Extract first {...} group from string
-func extractCurly(s string) string {
-	i1 := strings.Index(s, "{")
-	i2 := strings.Index(s, "}")
-	if i1 >= 0 && i2 > i1 {
-		return strings.TrimSpace(s[i1+1 : i2])
+// feed advances the state machine by one line
+func (m *machine) feed(t token) {
+	// Remember the first date and all-caps line anywhere as fallbacks
+	if t.kind == kindDate && !m.firstDate.found() {
+		m.firstDate = lineRange{t.index, t.index + 1}
+		m.firstDateValue = m.scanned[t.index].date
+	}
+	if t.kind == kindCaps && !m.fallbackTitle.found() && !m.template.isBanner(t.text) {
+		m.fallbackTitle = lineRange{t.index, t.index + 1}
 	}
-	return ""
-}
 
-func findCapsSectionAfter(lines []string, after string) string {
-	found := false
-	for _, l := range lines {
-		if found && isLikelyTitle(l) {
-			return strings.Trim(l, "{} ")
+	switch m.section {
+	case sectionHeader:
+		switch {
+		case t.kind == kindRead:
+			if ref, ok := m.readingOnReadLine(t.raw); ok {
+				m.setReading(t, ref)
+			} else {
+				m.section = sectionReading
+			}
+		case t.kind == kindQuestions && m.ahead(FieldReflectionQs):
+			m.startQuestions(t)
+		case directRefPattern.MatchString(t.text) && t.kind != kindVerse && m.ahead(FieldPassage):
+			// No "Read" line, but a bare reference that the verses follow
+			m.section = sectionPassageAt
+		}
+
+	case sectionReading:
+		if t.kind != kindBlank && t.kind != kindDate {
+			ref := t.text
+			if strings.HasPrefix(ref, "{") && strings.HasSuffix(ref, "}") {
+				ref = extractCurly(ref)
+			}
+			m.setReading(t, ref)
+			return
+		}
+		m.section = m.next(FieldReading)
+		m.feed(t)
+
+	case sectionDate:
+		switch t.kind {
+		case kindBlank:
+		case kindDate:
+			m.date = lineRange{t.index, t.index + 1}
+			m.dateValue = m.scanned[t.index].date
+			m.devo.Date = m.dateValue.Display
+			m.dateAfterRead = true
+			m.section = m.next(FieldDate)
+		default:
+			m.section = m.next(FieldDate)
+			m.feed(t)
+		}
+
+	case sectionVersion:
+		switch {
+		case t.kind == kindQuestions && m.ahead(FieldReflectionQs):
+			m.startQuestions(t)
+		case t.kind == kindVerse && m.ahead(FieldPassage):
+			m.startPassage(t)
+		case m.devo.Reading != "" && t.kind != kindDate && t.kind != kindRead && strings.Contains(t.text, m.devo.Reading):
+			after := strings.TrimSpace(t.text[strings.Index(t.text, m.devo.Reading)+len(m.devo.Reading):])
+			if code, info := findVersion(after); code != "" {
+				m.devo.Version = code
+				m.devo.VersionInfo = info
+				m.version = lineRange{t.index, t.index + 1}
+			}
+			m.section = m.next(FieldVersion)
+		case m.devo.Reading == "" && directRefPattern.MatchString(t.text):
+			m.section = m.next(FieldVersion)
+		}
+
+	case sectionPassageAt:
+		switch {
+		case t.kind == kindVerse:
+			m.startPassage(t)
+		case t.kind == kindQuestions && m.ahead(FieldReflectionQs):
+			m.startQuestions(t)
+		case t.kind == kindCaps && m.next(FieldPassage) == sectionTitle:
+			m.setTitle(t)
+		}
+
+	case sectionPassage:
+		switch {
+		case t.kind == kindQuestions && m.ahead(FieldReflectionQs):
+			m.startQuestions(t)
+		case t.kind == kindCaps && m.next(FieldPassage) == sectionTitle:
+			// A title right after the passage ends it
+			m.setTitle(t)
+		}
+
+	case sectionQuestionsAt:
+		if t.kind == kindQuestions {
+			m.startQuestions(t)
+		}
+
+	case sectionQuestions:
+		switch t.kind {
+		case kindBlank, kindQuestions:
+		case kindCaps:
+			if m.ahead(FieldTitle) {
+				m.setTitle(t)
+				return
+			}
+			fallthrough
+		default:
+			if m.startAhead(t) {
+				return
+			}
+			m.devo.ReflectionQs = append(m.devo.ReflectionQs, t.text)
+			if !m.questions.found() {
+				m.questions.start = t.index
+			}
+			m.questions.end = t.index + 1
 		}
-		if strings.Contains(strings.ToUpper(l), strings.ToUpper(after)) {
-			found = true
+
+	case sectionTitle:
+		if t.kind == kindCaps {
+			m.setTitle(t)
+		} else {
+			m.startAhead(t)
 		}
-	}
-	return ""
-}
 
-// grabPrayerSection specifically looks for "PRAYER" in all caps and captures everything after it
-func grabPrayerSection(lines []string) string {
-	startIdx := -1
-	for i, l := range lines {
-		// Look for exact "PRAYER" in all caps (can be surrounded by other text)
-		if strings.Contains(l, "PRAYER") {
-			startIdx = i + 1 // start from the line after "PRAYER"
-			break
+	case sectionAuthor:
+		if t.kind != kindBlank && t.kind != kindCaps && !m.mentionsPrayer(t.text) {
+			m.devo.Author = m.template.author(t.raw)
+			m.author = lineRange{t.index, t.index + 1}
+			m.section = m.next(FieldAuthor)
+			return
+		}
+		m.section = m.next(FieldAuthor)
+		m.feed(t)
+
+	case sectionBody:
+		if !m.body.found() {
+			m.body = lineRange{t.index, len(m.lines)}
 		}
+		m.startAhead(t)
+
+	case sectionPrayer:
+		// Everything up to the next field's header belongs to the prayer
+		m.startAhead(t)
+
+	case sectionEnd:
 	}
+}
 
-	if startIdx >= 0 && startIdx < len(lines) {
-		// Capture everything from after "PRAYER" to the end
-		return strings.TrimSpace(strings.Join(lines[startIdx:], "\n"))
+// startAhead starts the field a header line opens when the template has it
+// further on, reporting whether it did. Sections without a header of their
+// own end there.
+func (m *machine) startAhead(t token) bool {
+	switch {
+	case t.kind == kindRead && m.ahead(FieldReading):
+		m.end(t)
+		m.section = sectionHeader
+		m.feed(t)
+	case t.kind == kindQuestions && m.ahead(FieldReflectionQs):
+		m.startQuestions(t)
+	case t.kind == kindPrayer && m.ahead(FieldPrayer):
+		m.startPrayer(t)
+	default:
+		return false
 	}
-	return ""
+	return true
 }
 
-// normalizeDate ensures the date is in consistent "Month Day, Year" format
-func normalizeDate(dateStr string) string {
-	if dateStr == "" {
-		return ""
+// end closes the multi-line field being read at the line starting the next
+func (m *machine) end(t token) {
+	switch m.section {
+	case sectionPassage:
+		m.passage.end = t.index
+	case sectionBody:
+		m.body.end = t.index
+	case sectionPrayer:
+		m.prayer.end = t.index
 	}
+}
+
+// setReading records the reading reference found on a line
+func (m *machine) setReading(t token, ref string) {
+	m.devo.Reading = ref
+	m.reading = lineRange{t.index, t.index + 1}
+	m.section = m.next(FieldReading)
+}
 
-	// The regex already ensures the format is "Month Day, Year"
-	// This function can be extended later if we need to handle other date formats
-	dateStr = strings.TrimSpace(dateStr)
+// startPassage starts the passage at the first verse line
+func (m *machine) startPassage(t token) {
+	m.end(t)
+	m.passage = lineRange{t.index, len(m.lines)}
+	m.section = sectionPassage
+}
 
-	// Ensure proper spacing around comma (in case of variations like "August 2,2025")
-	re := regexp.MustCompile(`([A-Za-z]+) ([0-9]{1,2}),?\s*([0-9]{4})`)
-	matches := re.FindStringSubmatch(dateStr)
-	if len(matches) == 4 {
-		return matches[1] + " " + matches[2] + ", " + matches[3]
-	}
+// startQuestions enters the reflection questions section
+func (m *machine) startQuestions(t token) {
+	m.end(t)
+	m.questionsHeader = lineRange{t.index, t.index + 1}
+	m.section = sectionQuestions
+}
 
-	return dateStr
+// setTitle records the title and expects the field after it
+func (m *machine) setTitle(t token) {
+	m.end(t)
+	m.devo.Title = strings.Trim(t.text, "{} ")
+	m.title = lineRange{t.index, t.index + 1}
+	m.section = m.next(FieldTitle)
 }
 
-// isDateLine checks if a line looks like a date (with or without braces)
-func isDateLine(line string) bool {
-	line = strings.TrimSpace(line)
-	// Check for braced date format
-	reBraced := regexp.MustCompile(`^\{(January|February|March|April|May|June|July|August|September|October|November|December) [0-9]{1,2}, [0-9]{4}\}$`)
-	if reBraced.MatchString(line) {
-		return true
+// mentionsPrayer reports whether a line mentions one of the prayer headers
+func (m *machine) mentionsPrayer(line string) bool {
+	upper := strings.ToUpper(line)
+	for _, header := range m.template.Headers.Prayer {
+		if strings.Contains(upper, header) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Check for unbraced date format
-	reUnbraced := regexp.MustCompile(`^(January|February|March|April|May|June|July|August|September|October|November|December) [0-9]{1,2}, [0-9]{4}$`)
-	return reUnbraced.MatchString(line)
+// startPrayer enters the prayer section at its header
+func (m *machine) startPrayer(t token) {
+	m.end(t)
+	m.prayerHeader = lineRange{t.index, t.index + 1}
+	m.prayer = lineRange{t.index + 1, len(m.lines)}
+	m.section = sectionPrayer
 }
 
-// findBibleVersion finds the Bible version (like NIV, ESV, NASB, AMPLIFIED, MESSAGE) after the reading reference
-func findBibleVersion(lines []string, reading string) string {
-	if reading == "" {
-		return ""
+// finish fills in the multi-line fields and applies fallbacks
+func (m *machine) finish() {
+	if m.devo.Date == "" && m.firstDate.found() {
+		m.dateValue = m.firstDateValue
+		m.devo.Date = m.dateValue.Display
+		m.date = m.firstDate
 	}
 
-	// Look for lines that contain the reading reference followed by a version
-	for _, l := range lines {
-		l = strings.TrimSpace(l)
-		// Skip empty lines and lines that are just the date
-		if l == "" || isDateLine(l) {
-			continue
-		}
+	if m.passage.found() {
+		m.devo.Passage = strings.Trim(strings.TrimSpace(strings.Join(m.lines[m.passage.start:m.passage.end], "\n")), "{} \n")
+		m.passage = trimRange(m.lines, m.passage)
+	}
 
-		// Look for lines that start with the reading reference
-		if strings.HasPrefix(l, reading) {
-			// Extract everything after the reading reference
-			afterReading := strings.TrimSpace(l[len(reading):])
-			if afterReading != "" {
-				// Bible versions pattern - can be 2+ uppercase letters or common longer names
-				versionRegex := regexp.MustCompile(`^([A-Z]{2,}|AMPLIFIED|MESSAGE|PHILLIPS|CONTEMPORARY|LIVING|PASSION|VOICE)\b`)
-				matches := versionRegex.FindStringSubmatch(afterReading)
-				if len(matches) > 1 {
-					return matches[1]
-				}
+	// Hashtags, links and sign-offs closing the post are its footer, not part
+	// of the prayer, or of the body when there is no prayer
+	if start := findFooter(m.lines); start < len(m.lines) {
+		for _, r := range []*lineRange{&m.prayer, &m.body} {
+			if r.found() && r.end == len(m.lines) && start >= r.start {
+				r.end = start
+				m.footer = trimRange(m.lines, lineRange{start, len(m.lines)})
+				m.devo.Footer = strings.Join(m.lines[m.footer.start:m.footer.end], "\n")
 			}
 		}
+	}
 
-		// Also check for patterns like "Matthew 6:16-18 NIV" anywhere in the line
-		if strings.Contains(l, reading) {
-			// Find the position after the reading reference
-			idx := strings.Index(l, reading) + len(reading)
-			if idx < len(l) {
-				afterReading := strings.TrimSpace(l[idx:])
-				versionRegex := regexp.MustCompile(`^([A-Z]{2,}|AMPLIFIED|MESSAGE|PHILLIPS|CONTEMPORARY|LIVING|PASSION|VOICE)\b`)
-				matches := versionRegex.FindStringSubmatch(afterReading)
-				if len(matches) > 1 {
-					return matches[1]
-				}
+	if m.body.found() {
+		m.body = trimRange(m.lines, m.body)
+		if m.body.found() {
+			m.devo.Body = strings.Trim(strings.Join(m.lines[m.body.start:m.body.end], "\n"), "{} \n")
+			if !m.prayer.found() {
+				m.devo.Body = trailingTagsPattern.ReplaceAllString(m.devo.Body, "")
 			}
 		}
 	}
 
-	return ""
-}
-
-// grabPassageAfterVersion extracts the passage text that comes after the version line
-func grabPassageAfterVersion(lines []string, reading string, version string) string {
-	if reading == "" {
-		// If no reading found, try to find Bible reference pattern and get verses after it
-		return grabPassageFromDirectReference(lines)
-	}
-
-	startIdx := -1
-	for i, l := range lines {
-		l = strings.TrimSpace(l)
-		// Look for the line that contains the reading and version
-		if strings.Contains(l, reading) && (version == "" || strings.Contains(l, version)) {
-			// Look for the first line after this that starts with a verse number
-			for j := i + 1; j < len(lines); j++ {
-				nextLine := strings.TrimSpace(lines[j])
-				if nextLine == "" {
-					continue
-				}
-				// Check if this looks like a verse (starts with number)
-				if isVerseStart(nextLine) {
-					startIdx = j
-					break
-				}
-			}
-			break
-		}
+	if m.prayer.found() {
+		m.devo.Prayer = strings.Trim(strings.TrimSpace(strings.Join(m.lines[m.prayer.start:m.prayer.end], "\n")), "{} \n")
+		m.devo.Prayer = trailingTagsPattern.ReplaceAllString(m.devo.Prayer, "")
+		m.prayer = trimRange(m.lines, m.prayer)
 	}
 
-	// If we found the start of verses, extract until "REFLECTION QUESTIONS"
-	if startIdx >= 0 && startIdx < len(lines) {
-		endIdx := len(lines)
-		// Look for "REFLECTION QUESTIONS" to know where to stop
-		for i := startIdx; i < len(lines); i++ {
-			if strings.Contains(strings.ToUpper(lines[i]), "REFLECTION QUESTIONS") {
-				endIdx = i
-				break
+	fields := map[string]lineRange{
+		FieldReading: m.reading, FieldDate: m.date, FieldVersion: m.version, FieldPassage: m.passage,
+		FieldReflectionQs: m.questions, FieldTitle: m.title, FieldAuthor: m.author, FieldBody: m.body, FieldPrayer: m.prayer,
+	}
+	for field, r := range fields {
+		if style := m.style(r); style != (models.TextStyle{}) {
+			if m.devo.Styles == nil {
+				m.devo.Styles = make(map[string]models.TextStyle)
 			}
+			m.devo.Styles[field] = style
 		}
+	}
+}
 
-		if startIdx < endIdx {
-			return strings.TrimSpace(strings.Join(lines[startIdx:endIdx], "\n"))
-		}
+// derive fills in the fields computed from the others. Templates aren't
+// scored on them, so only the chosen template's machine derives them.
+func (m *machine) derive() {
+	if m.devo.Passage != "" {
+		m.devo.Verses = parseVerses(m.devo.Passage, m.devo.Reading)
 	}
+	if m.devo.Body != "" {
+		m.devo.Citations = extractCitations(m.devo.Body, m.devo.Reading)
+		lines := strings.Split(m.devo.Body, "\n")
+		var styles []models.TextStyle
+		if m.body.found() && m.body.start+len(lines) <= len(m.styles) {
+			styles = m.styles[m.body.start : m.body.start+len(lines)]
+		}
+		m.devo.BodyBlocks, _ = splitBody(lines, styles, m.devo.Reading)
+	}
+	m.devo.MemoryVerse = MemoryVerse(m.devo.Verses, m.devo.Citations, m.devo.Reading)
+	m.devo.PullQuote = PullQuote(m.devo.BodyBlocks)
+	m.devo.Series = extractSeries(m.devo.Title, m.devo.Body)
+	m.devo.Hashtags, m.devo.URLs, m.devo.Mentions = extractTags(m.lines)
 
-	return ""
+	m.devo.Language = languageOf(m.devo)
 }
 
-// grabPassageFromDirectReference handles passages when there's a direct Bible reference
-func grabPassageFromDirectReference(lines []string) string {
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// Look for Bible reference pattern (e.g., "Revelation 7:9-12 NIV")
-		re := regexp.MustCompile(`^([1-3]?\s*[A-Za-z]+(?:\s+[A-Za-z]+)*)\s+(\d+:\d+(?:-\d+)?)\s*([A-Z]{2,4})?`)
-		if re.MatchString(trimmed) {
-			// Found the reference line, look for verses after it
-			for j := i + 1; j < len(lines); j++ {
-				nextLine := strings.TrimSpace(lines[j])
-				if nextLine == "" {
-					continue
-				}
-				// Check if this looks like a verse (starts with number)
-				if isVerseStart(nextLine) {
-					// Find the end (before REFLECTION QUESTIONS)
-					endIdx := len(lines)
-					for k := j; k < len(lines); k++ {
-						if strings.Contains(strings.ToUpper(lines[k]), "REFLECTION QUESTIONS") {
-							endIdx = k
-							break
-						}
-					}
-					return strings.TrimSpace(strings.Join(lines[j:endIdx], "\n"))
-				}
-			}
-		}
-	}
-	return ""
+// languageOf detects the language of a devotional. The passage is left out,
+// it is in the language of the Bible version.
+func languageOf(devo models.Devotional) string {
+	return detectLanguage(strings.Join(append([]string{devo.Title, devo.Body, devo.Prayer}, devo.ReflectionQs...), "\n"))
 }
 
-// isVerseStart checks if a line starts with a verse number
-func isVerseStart(line string) bool {
-	// Match patterns like "9 After this...", "10 And they...", etc.
-	re := regexp.MustCompile(`^\d+\s+`)
-	return re.MatchString(line)
-}
-
-// getReflectionQuestions properly extracts reflection questions without mixing in title
-func getReflectionQuestions(lines []string) []string {
-	var questions []string
-	inQuestions := false
-	
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		
-		// Start capturing after "REFLECTION QUESTIONS"
-		if strings.Contains(strings.ToUpper(trimmed), "REFLECTION QUESTIONS") {
-			inQuestions = true
-			continue
-		}
-		
-		// Stop when we hit the title section (all caps line after questions)
-		if inQuestions && isLikelyTitle(trimmed) {
-			break
-		}
-		
-		// Add non-empty lines while in questions section
-		if inQuestions && trimmed != "" {
-			questions = append(questions, trimmed)
-		}
+// Derive recomputes the fields derived from the text of a devotional after
+// it was edited: the verses, citations, body blocks, memory verse, pull
+// quote, series and language
+func Derive(devo models.Devotional) models.Devotional {
+	devo.Verses, devo.Citations, devo.BodyBlocks = nil, nil, nil
+	if devo.Passage != "" {
+		devo.Verses = parseVerses(devo.Passage, devo.Reading)
 	}
-	
-	return questions
-}
-
-// findActualTitle finds the actual devotional title (usually after reflection questions)
-func findActualTitle(lines []string) string {
-	foundQuestions := false
-	
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		
-		// Track when we've passed reflection questions
-		if strings.Contains(strings.ToUpper(trimmed), "REFLECTION QUESTIONS") {
-			foundQuestions = true
-			continue
-		}
-		
-		// After reflection questions, look for all-caps title
-		if foundQuestions && isLikelyTitle(trimmed) {
-			return strings.Trim(trimmed, "{} ")
-		}
-	}
-	
-	// Fallback: look for any likely title
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if isLikelyTitle(trimmed) && !strings.Contains(trimmed, "DAILY DEVOTIONAL") && !strings.Contains(trimmed, "REFLECTION") {
-			return strings.Trim(trimmed, "{} ")
-		}
+	if devo.Body != "" {
+		devo.Citations = extractCitations(devo.Body, devo.Reading)
+		devo.BodyBlocks = BodyBlocks(devo.Body, devo.Reading)
 	}
-	
-	return ""
+	devo.MemoryVerse = MemoryVerse(devo.Verses, devo.Citations, devo.Reading)
+	devo.PullQuote = PullQuote(devo.BodyBlocks)
+	devo.Series = extractSeries(devo.Title, devo.Body)
+	devo.Language = languageOf(devo)
+	return devo
 }
 
-// findAuthorAfterTitle finds the author line immediately after the title
-func findAuthorAfterTitle(lines []string, title string) string {
-	if title == "" {
-		return ""
-	}
-	
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// Find the title line
-		if strings.Contains(trimmed, title) {
-			// Check the next line for author
-			if i+1 < len(lines) {
-				nextLine := strings.TrimSpace(lines[i+1])
-				if nextLine != "" && !isLikelyTitle(nextLine) && !strings.Contains(strings.ToUpper(nextLine), "PRAYER") {
-					return strings.Trim(nextLine, "{} ")
-				}
-			}
-		}
+// style combines the styling of the lines in a range
+func (m *machine) style(r lineRange) models.TextStyle {
+	var style models.TextStyle
+	for i := r.start; r.found() && i < r.end; i++ {
+		style.Bold = style.Bold || m.styles[i].Bold
+		style.Italic = style.Italic || m.styles[i].Italic
 	}
-	
-	return ""
+	return style
 }
 
-// grabDevotionalBody extracts the main devotional text between author and prayer
-func grabDevotionalBody(lines []string, title, author string) string {
-	startIdx := -1
-	endIdx := len(lines)
-	// DEBUG: Print lines for inspection
-	// fmt.Println("DEBUG: lines:")
-	// for i, l := range lines {
-	// 	fmt.Printf("%d: %q\n", i, l)
-	// }
+// trimRange shrinks a range to exclude leading and trailing blank lines
+func trimRange(lines []string, r lineRange) lineRange {
+	for r.start < r.end && strings.TrimSpace(lines[r.start]) == "" {
+		r.start++
+	}
+	for r.end > r.start && strings.TrimSpace(lines[r.end-1]) == "" {
+		r.end--
+	}
+	if r.start >= r.end {
+		return newRange()
+	}
+	return r
+}
 
+// ParseDevotional parses a Facebook post message into a Devotional struct,
+// using the registered template that fits it best
+func ParseDevotional(msg string) models.Devotional {
+	return detect(msg).devo
+}
 
-	// Find where to start (after author line)
-	if author != "" {
-		for i, line := range lines {
-			if strings.Contains(strings.TrimSpace(line), author) {
-				startIdx = i + 1
-				break
-			}
-		}
-	} else if title != "" {
-		// If no author, start after title
-		for i, line := range lines {
-			if strings.Contains(strings.TrimSpace(line), title) {
-				startIdx = i + 1
-				break
-			}
-		}
+// readingOnReadLine extracts the reading reference from a "Read ..." line,
+// handling both braced and unbraced formats. ok is false when the reference
+// is expected on the next line instead.
+func (m *machine) readingOnReadLine(line string) (string, bool) {
+	prefix, _ := m.template.readingPrefix(line)
+	reading := strings.TrimSpace(line[len(prefix):])
+	if strings.Contains(reading, "{") && strings.Contains(reading, "}") {
+		return extractCurly(reading), true
 	}
+	return reading, reading != ""
+}
 
-	// Find where to end (before PRAYER)
-	for i := startIdx; i < len(lines); i++ {
-		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(lines[i])), "PRAYER") {
-			endIdx = i
-			break
-		}
+// findVersion reads the version code following the reading reference. Codes
+// in the catalogue come with their metadata; an unlisted code is kept only
+// when it looks like one (e.g. "XYZ") and ends the line, so capitalized
+// words opening a sentence ("GOD is faithful") are not taken for versions.
+func findVersion(after string) (string, *scripture.Version) {
+	match := versionPattern.FindStringSubmatch(after)
+	if match == nil {
+		return "", nil
 	}
-
-	if startIdx >= 0 && startIdx < endIdx {
-		// Join all lines between startIdx and endIdx, preserving blank lines and paragraphs
-	// DEBUG: Print startIdx, endIdx, and body lines
-	// fmt.Printf("DEBUG: startIdx=%d, endIdx=%d\n", startIdx, endIdx)
-	// if startIdx >= 0 && startIdx < endIdx {
-	// 	bodyLines := lines[startIdx:endIdx]
-	// 	for i, l := range bodyLines {
-	// 		fmt.Printf("body[%d]: %q\n", i, l)
-	// 	}
-	// }
-		bodyLines := lines[startIdx:endIdx]
-		// Remove leading/trailing blank lines only
-		for len(bodyLines) > 0 && strings.TrimSpace(bodyLines[0]) == "" {
-			bodyLines = bodyLines[1:]
-		}
-		for len(bodyLines) > 0 && strings.TrimSpace(bodyLines[len(bodyLines)-1]) == "" {
-			bodyLines = bodyLines[:len(bodyLines)-1]
-		}
-		return strings.Join(bodyLines, "\n")
+	if info, ok := scripture.LookupVersion(match[1]); ok {
+		return match[1], info
+	}
+	if versionCodePattern.MatchString(match[1]) && strings.TrimSpace(after[len(match[0]):]) == "" {
+		return match[1], nil
 	}
+	return "", nil
+}
 
+// Extract first {...} group from string
+func extractCurly(s string) string {
+	i1 := strings.Index(s, "{")
+	i2 := strings.Index(s, "}")
+	if i1 >= 0 && i2 > i1 {
+		return strings.TrimSpace(s[i1+1 : i2])
+	}
 	return ""
 }
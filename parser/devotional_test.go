@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+// loadCorpus reads every post in testdata/posts, keyed by file name
+func loadCorpus(t testing.TB) map[string]string {
+	files, err := filepath.Glob(filepath.Join("testdata", "posts", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no posts found in testdata/posts")
+	}
+
+	posts := make(map[string]string, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		posts[filepath.Base(f)] = string(data)
+	}
+	return posts
+}
+
+// legacyFields are the fields the legacy parser produced, frozen as it
+// produced them: it cut edition suffixes off versions, "ESV-r" to "ESV"
+type legacyFields struct {
+	Date, Reading, Version, Passage string
+	ReflectionQs                    []string
+	Title, Author, Body, Prayer     string
+}
+
+func legacyView(d models.Devotional) legacyFields {
+	version, _, _ := strings.Cut(d.Version, "-")
+	return legacyFields{d.Date, d.Reading, version, d.Passage, d.ReflectionQs, d.Title, d.Author, d.Body, d.Prayer}
+}
+
+// legacyInput is the one normalization the comparison makes: the legacy
+// parser read posts as posted, so it is given the text the new parser reads,
+// normalized and without the footer and hashtags closing the post
+func legacyInput(msg string) string {
+	lines := strings.Split(NormalizeText(msg), "\n")
+	text := strings.TrimSpace(strings.Join(lines[:findFooter(lines)], "\n"))
+	return trailingTagsPattern.ReplaceAllString(text, "")
+}
+
+func TestParserMatchesLegacy(t *testing.T) {
+	for name, msg := range loadCorpus(t) {
+		t.Run(name, func(t *testing.T) {
+			got := ParseDevotional(msg)
+			if got.Template != "standard" {
+				t.Errorf("Expected the standard template, got %q", got.Template)
+			}
+			want := legacyParseDevotional(legacyInput(msg))
+			if !reflect.DeepEqual(legacyView(got), legacyView(want)) {
+				t.Errorf("ParseDevotional differs from the legacy parser\n got: %#v\nwant: %#v", legacyView(got), legacyView(want))
+			}
+		})
+	}
+}
+
+func TestParseSpans(t *testing.T) {
+	msg := loadCorpus(t)["2025-08-02-matthew-6.txt"]
+	result := Parse(msg)
+
+	for _, name := range []string{FieldDate, FieldReading, FieldVersion, FieldPassage, FieldReflectionQs, FieldTitle, FieldAuthor, FieldBody, FieldPrayer} {
+		field := result.Fields[name]
+		if field == nil || field.Span == nil {
+			t.Errorf("%s: expected a span", name)
+			continue
+		}
+		if field.Span.StartLine < 1 || field.Span.EndLine < field.Span.StartLine {
+			t.Errorf("%s: invalid span %+v", name, *field.Span)
+		}
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+	if author := result.Fields[FieldAuthor]; author != nil && author.Confidence != 1 {
+		t.Errorf("Expected full confidence in a found author, got %v", author.Confidence)
+	}
+}
+
+// BenchmarkParseDevotional and BenchmarkLegacyParseDevotional parse the
+// corpus with both parsers; compare them with benchstat:
+//
+//	go test ./parser -run '^$' -bench ParseDevotional -count 10 > bench.txt && benchstat bench.txt
+func BenchmarkParseDevotional(b *testing.B) {
+	posts := loadCorpus(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, msg := range posts {
+			ParseDevotional(msg)
+		}
+	}
+}
+
+func BenchmarkLegacyParseDevotional(b *testing.B) {
+	posts := loadCorpus(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, msg := range posts {
+			legacyParseDevotional(msg)
+		}
+	}
+}
+
+func TestFindVersion(t *testing.T) {
+	tests := []struct {
+		after string
+		want  string
+		known bool
+	}{
+		{"NIV", "NIV", true},
+		{"ESV-r", "ESV-r", true},
+		{"(NLT)", "NLT", true},
+		{"AMPLIFIED", "AMPLIFIED", true},
+		{"XYZ", "XYZ", false},
+		{"(XYZ)", "XYZ", false},
+		{"NIV God is our refuge", "NIV", true},
+		{"GOD is our refuge", "", false},
+		{"XYZ translation", "", false},
+		{"Today we read", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, info := findVersion(tt.after)
+		if got != tt.want || (info != nil) != tt.known {
+			t.Errorf("findVersion(%q) = %q, %+v; want %q, known %v", tt.after, got, info, tt.want, tt.known)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"lwnra-devo-api/models"
+)
+
+// FieldDiff is a difference in one devotional field between two parses
+type FieldDiff struct {
+	Field string      // JSON name of the field
+	Want  interface{} // expected value, nil if absent
+	Got   interface{} // actual value, nil if absent
+}
+
+// DiffDevotionals compares two devotionals field by field, using their JSON
+// representation, and returns the differing fields sorted by name
+func DiffDevotionals(want, got models.Devotional) []FieldDiff {
+	wantFields := devotionalFields(want)
+	gotFields := devotionalFields(got)
+
+	names := make(map[string]bool)
+	for name := range wantFields {
+		names[name] = true
+	}
+	for name := range gotFields {
+		names[name] = true
+	}
+
+	var diffs []FieldDiff
+	for name := range names {
+		if !reflect.DeepEqual(wantFields[name], gotFields[name]) {
+			diffs = append(diffs, FieldDiff{Field: name, Want: wantFields[name], Got: gotFields[name]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// devotionalFields returns the devotional's JSON fields by name
+func devotionalFields(devo models.Devotional) map[string]interface{} {
+	data, _ := json.Marshal(devo)
+	fields := make(map[string]interface{})
+	json.Unmarshal(data, &fields)
+	return fields
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	hashtagPattern = regexp.MustCompile(`(?:^|[\s(])#([\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*)`)
+	mentionPattern = regexp.MustCompile(`(?:^|[\s(])@([\p{L}\p{N}_][\p{L}\p{N}_.]*)`)
+	urlPattern     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+	// trailingTagsPattern matches hashtags at the end of a line, e.g. the
+	// " #Prayer #LWNRA" in "Amen. #Prayer #LWNRA"
+	trailingTagsPattern = regexp.MustCompile(`(?:\s+#[\p{L}\p{N}_]+)+\s*$`)
+)
+
+// footerPhrases start the page's sign-offs and calls to action that close a
+// post, matched case-insensitively
+var footerPhrases = []string{
+	"share this", "share with", "please share", "like and share", "like, share",
+	"follow us", "follow our", "visit us", "visit our", "for more devotionals",
+	"watch the", "watch our", "listen to", "read more",
+	"god bless", "blessings,", "in his service", "lwnra family", "- lwnra",
+}
+
+// maxSignOffWords is the most words a sign-off has when no hashtags, links
+// or mentions follow it; longer lines starting with a footer phrase are
+// ordinary sentences, e.g. "Listen to His voice today and be still."
+const maxSignOffWords = 6
+
+// isTagLine reports whether a line holds only hashtags, links and mentions,
+// or ends with a link
+func isTagLine(line string) bool {
+	lower := strings.ToLower(strings.TrimSpace(line))
+	if !strings.ContainsAny(lower, "#@") && !strings.Contains(lower, "://") && !strings.Contains(lower, "www.") {
+		return false
+	}
+	if loc := urlPattern.FindStringIndex(lower); loc != nil && loc[1] == len(lower) {
+		return true
+	}
+
+	rest := urlPattern.ReplaceAllString(line, " ")
+	rest = hashtagPattern.ReplaceAllString(rest, " ")
+	rest = mentionPattern.ReplaceAllString(rest, " ")
+	return strings.IndexFunc(rest, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) < 0 && strings.TrimSpace(rest) != strings.TrimSpace(line)
+}
+
+// isSignOff reports whether a line is the page's sign-off: it starts with a
+// footer phrase and is short, or is followed by hashtags, links or mentions.
+// A line saying "Amen" closes the prayer and is never a sign-off.
+func isSignOff(line string, beforeTags bool) bool {
+	lower := strings.ToLower(strings.TrimSpace(line))
+	if strings.Contains(lower, "amen") {
+		return false
+	}
+	for _, phrase := range footerPhrases {
+		if strings.HasPrefix(lower, phrase) {
+			return beforeTags || len(strings.Fields(lower)) <= maxSignOffWords
+		}
+	}
+	return false
+}
+
+// findFooter returns the first line of the footer block closing the post, or
+// len(lines) when there is none. The footer is the run of tag lines and
+// sign-offs, possibly separated by blank lines, up to the end of the post.
+func findFooter(lines []string) int {
+	start := len(lines)
+	beforeTags := false
+	for i := len(lines) - 1; i >= 0; i-- {
+		switch {
+		case strings.TrimSpace(lines[i]) == "":
+		case isTagLine(lines[i]):
+			start, beforeTags = i, true
+		case isSignOff(lines[i], beforeTags):
+			start = i
+		default:
+			return start
+		}
+	}
+	return start
+}
+
+// extractTags collects the unique hashtags, URLs and mentions of the lines of
+// a post, in order of appearance, without their "#" and "@"
+func extractTags(lines []string) (hashtags, urls, mentions []string) {
+	// Most lines have none, so the patterns only run on lines that may
+	for _, line := range lines {
+		if strings.Contains(line, "#") {
+			for _, m := range hashtagPattern.FindAllStringSubmatch(line, -1) {
+				hashtags = appendUnique(hashtags, m[1])
+			}
+		}
+		if strings.Contains(line, "://") || strings.Contains(strings.ToLower(line), "www.") {
+			for _, u := range urlPattern.FindAllString(line, -1) {
+				urls = appendUnique(urls, strings.TrimRight(u, ".,;:!?)'"))
+			}
+		}
+		if strings.Contains(line, "@") {
+			for _, m := range mentionPattern.FindAllStringSubmatch(line, -1) {
+				mentions = appendUnique(mentions, strings.TrimRight(m[1], "."))
+			}
+		}
+	}
+	return hashtags, urls, mentions
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
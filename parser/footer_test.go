@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractTags(t *testing.T) {
+	msg := "Email pastor@lwnra.org or visit www.lwnra.org. We are #1 in His love!\n" +
+		"Watch (https://example.com/live), tag @Grace.Dela.Cruz and share #DailyDevotional #Pag-asa #DailyDevotional"
+
+	hashtags, urls, mentions := extractTags(strings.Split(msg, "\n"))
+	if want := []string{"DailyDevotional", "Pag"}; !reflect.DeepEqual(hashtags, want) {
+		t.Errorf("Expected hashtags %v, got %v", want, hashtags)
+	}
+	if want := []string{"www.lwnra.org", "https://example.com/live"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("Expected URLs %v, got %v", want, urls)
+	}
+	if want := []string{"Grace.Dela.Cruz"}; !reflect.DeepEqual(mentions, want) {
+		t.Errorf("Expected mentions %v, got %v", want, mentions)
+	}
+}
+
+func TestFooterWithoutPrayer(t *testing.T) {
+	msg := "DAILY DEVOTIONAL\nRead Philippians 4:6-7\nAugust 15, 2025\nPhilippians 4:6-7 NKJV\n6 Be anxious for nothing...\n" +
+		"REFLECTION QUESTIONS\nWhat worries you?\nPEACE IN PRAYER\nGrace Dela Cruz\n" +
+		"Bring everything to God (v. 6). #Peace\n\nLike and share!\n#DailyDevotional"
+
+	devo := ParseDevotional(msg)
+	if devo.Body != "Bring everything to God (v. 6)." {
+		t.Errorf("Expected the body without footer and hashtags, got %q", devo.Body)
+	}
+	if devo.Footer != "Like and share!\n#DailyDevotional" {
+		t.Errorf("Expected the footer, got %q", devo.Footer)
+	}
+	if want := []string{"Peace", "DailyDevotional"}; !reflect.DeepEqual(devo.Hashtags, want) {
+		t.Errorf("Expected hashtags %v, got %v", want, devo.Hashtags)
+	}
+}
+
+func TestFooterKeepsClosingSentences(t *testing.T) {
+	head := "DAILY DEVOTIONAL\nRead Philippians 4:6-7\nAugust 15, 2025\nPhilippians 4:6-7 NKJV\n6 Be anxious for nothing...\n" +
+		"REFLECTION QUESTIONS\nWhat worries you?\nPEACE IN PRAYER\nGrace Dela Cruz\nBring everything to God (v. 6).\n"
+
+	// A prayer ending with a line that starts like a sign-off
+	devo := ParseDevotional(head + "PRAYER\nLord, thank You for Your peace.\nGod bless our families and keep them in Your care. Amen.")
+	if devo.Prayer != "Lord, thank You for Your peace.\nGod bless our families and keep them in Your care. Amen." || devo.Footer != "" {
+		t.Errorf("Expected the whole prayer and no footer, got prayer %q, footer %q", devo.Prayer, devo.Footer)
+	}
+
+	// A body ending with a sentence that starts like a call to action
+	devo = ParseDevotional(head + "Listen to His voice today and be still.")
+	if devo.Body != "Bring everything to God (v. 6).\nListen to His voice today and be still." || devo.Footer != "" {
+		t.Errorf("Expected the whole body and no footer, got body %q, footer %q", devo.Body, devo.Footer)
+	}
+
+	// The same kind of line before a link is the page's call to action
+	devo = ParseDevotional(head + "PRAYER\nAmen.\n\nListen to the full sermon on our page this Sunday\nhttps://www.facebook.com/lwnra")
+	if devo.Prayer != "Amen." || devo.Footer != "Listen to the full sermon on our page this Sunday\nhttps://www.facebook.com/lwnra" {
+		t.Errorf("Expected the call to action in the footer, got prayer %q, footer %q", devo.Prayer, devo.Footer)
+	}
+}
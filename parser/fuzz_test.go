@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// addCorpus seeds a fuzz target with the posts of the regression corpus
+func addCorpus(f *testing.F) {
+	for _, msg := range loadCorpus(f) {
+		f.Add(msg)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, msg string) {
+		result := Parse(msg)
+		devo := result.Devotional
+		if utf8.ValidString(msg) {
+			for _, field := range []string{devo.Reading, devo.Passage, devo.Title, devo.Author, devo.Body, devo.Prayer} {
+				if !utf8.ValidString(field) {
+					t.Errorf("field %q is not valid UTF-8", field)
+				}
+			}
+		}
+		for _, c := range devo.Citations {
+			if c.Start < 0 || c.End < c.Start || c.End > utf16Len(devo.Body) {
+				t.Errorf("citation %+v is outside the body", c)
+			}
+		}
+		RenderMarkdown(devo)
+		RenderBodyHTML(devo.BodyBlocks)
+		RenderBodyMarkdown(devo.BodyBlocks)
+		Render(devo)
+	})
+}
+
+func FuzzValidate(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, msg string) {
+		v := Validate(msg)
+		for _, issue := range append(v.Errors, v.Warnings...) {
+			if issue.Line < 1 {
+				t.Errorf("issue %+v is before the first line", issue)
+			}
+		}
+	})
+}
+
+func FuzzNormalizeText(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		normalized := NormalizeText(s)
+		if utf8.ValidString(s) && !utf8.ValidString(normalized) {
+			t.Errorf("NormalizeText(%q) = %q is not valid UTF-8", s, normalized)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+// TestGolden parses every post in the corpus and compares the result with the
+// expected JSON stored next to it. Regenerate the expected files with
+// "go run ./cmd/parsecheck -update" after an intended parser change.
+func TestGolden(t *testing.T) {
+	var posts []string
+	for _, dir := range []string{"posts", "templates"} {
+		files, err := filepath.Glob(filepath.Join("testdata", dir, "*.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		posts = append(posts, files...)
+	}
+
+	for _, post := range posts {
+		t.Run(filepath.Base(post), func(t *testing.T) {
+			msg, err := os.ReadFile(post)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(strings.TrimSuffix(post, ".txt") + ".json")
+			if err != nil {
+				t.Fatalf("missing golden file: %v", err)
+			}
+
+			var want models.Devotional
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatalf("invalid golden file: %v", err)
+			}
+
+			for _, d := range DiffDevotionals(want, ParseDevotional(string(msg))) {
+				t.Errorf("%s:\n want: %v\n  got: %v", d.Field, d.Want, d.Got)
+			}
+		})
+	}
+}
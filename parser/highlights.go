@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+var (
+	// sentenceEndPattern matches the end of a sentence and the space after it
+	sentenceEndPattern = regexp.MustCompile(`[.!?]+["')]*\s+`)
+
+	// pullQuoteFaithWords and pullQuoteReaderWords make a sentence worth
+	// carrying: it speaks of God, or to the reader
+	pullQuoteFaithWords  = wordSet("god jesus lord christ spirit faith grace hope love diyos panginoon hesus kristo pananampalataya biyaya pag-asa pag-ibig")
+	pullQuoteReaderWords = wordSet("you your we us our ikaw mo ka tayo natin kita")
+
+	// pullQuoteContextWords open sentences that lean on the one before them
+	pullQuoteContextWords = wordSet("and but so this that it he she they these ngunit pero kaya ito siya sila")
+)
+
+// MemoryVerse picks the verse of a passage the body cites most, the first
+// verse when none is cited. It is nil without verses or a reading of a
+// single book.
+func MemoryVerse(verses []models.Verse, citations []models.Citation, reading string) *models.MemoryVerse {
+	refs, err := scripture.Parse(reading)
+	if err != nil || len(refs) != 1 || len(verses) == 0 {
+		return nil
+	}
+	book := refs[0].Book
+
+	best, bestCount := 0, 0
+	for i, v := range verses {
+		count := 0
+		for _, c := range citations {
+			if c.Book == book.Name && (scripture.Reference{Book: book, Ranges: c.Ranges}).Contains(v.Chapter, v.Number) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+
+	v := verses[best]
+	ref := scripture.Reference{Book: book, Ranges: []scripture.Range{{StartChapter: v.Chapter, StartVerse: v.Number, EndChapter: v.Chapter, EndVerse: v.Number}}}
+	return &models.MemoryVerse{Reference: ref.String(), Text: v.Text, Citations: bestCount}
+}
+
+// PullQuote picks the most quotable sentence of a body: a short statement
+// the author set apart as a quote or in bold or italics, that speaks of God
+// or to the reader and stands on its own. Questions, citations and scripture
+// quotes are passed over. Ties go to the earliest sentence.
+func PullQuote(blocks []models.BodyBlock) string {
+	quote, bestScore := "", 0
+	for _, block := range blocks {
+		if block.Type != BlockParagraph && block.Type != BlockQuote {
+			continue
+		}
+		setApart := block.Type == BlockQuote || block.Bold || block.Italic
+		for _, sentence := range sentences(block.Text) {
+			if score := pullQuoteScore(sentence, setApart); score > bestScore {
+				quote, bestScore = emphasisPattern.ReplaceAllString(sentence, "$1$2"), score
+			}
+		}
+	}
+	return quote
+}
+
+// sentences splits text into sentences, joining its lines
+func sentences(text string) []string {
+	text = strings.Join(strings.Fields(text), " ")
+	var result []string
+	last := 0
+	for _, loc := range sentenceEndPattern.FindAllStringIndex(text, -1) {
+		result = append(result, strings.TrimSpace(text[last:loc[1]]))
+		last = loc[1]
+	}
+	if rest := strings.TrimSpace(text[last:]); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
+
+// pullQuoteScore rates how quotable a sentence is, 0 when it is not
+func pullQuoteScore(sentence string, setApart bool) int {
+	plain := sentence
+	if strings.ContainsAny(sentence, "*_") {
+		plain = emphasisPattern.ReplaceAllString(sentence, "$1$2")
+	}
+	words := strings.FieldsFunc(strings.ToLower(plain), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '\''
+	})
+	first := []rune(strings.TrimLeft(sentence, `"'*_`))
+	switch {
+	case len(words) < 4 || len(words) > 30,
+		len(first) == 0 || !unicode.IsUpper(first[0]),
+		strings.HasSuffix(strings.TrimRight(sentence, `"')*_`), "?"),
+		strings.ContainsAny(sentence, "()0123456789:"):
+		return 0
+	}
+
+	score := 1
+	if setApart {
+		score += 2
+	} else if plain != sentence {
+		score++
+	}
+	if len(words) >= 6 && len(words) <= 20 {
+		score++
+	}
+	if pullQuoteContextWords[words[0]] {
+		score--
+	}
+	var faith, reader bool
+	for _, w := range words {
+		faith = faith || pullQuoteFaithWords[w]
+		reader = reader || pullQuoteReaderWords[w]
+	}
+	if faith {
+		score++
+	}
+	if reader {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+func TestMemoryVerse(t *testing.T) {
+	passage := "1 God is our refuge and strength, an ever-present help in trouble.\n" +
+		"2 Therefore we will not fear, though the earth give way.\n" +
+		"10 He says, \"Be still, and know that I am God.\""
+	tests := []struct {
+		name    string
+		body    string
+		reading string
+		want    *models.MemoryVerse
+	}{
+		{"most cited", "Be still (v. 10). He is our refuge (v. 1). Be still and know (Psalm 46:10).", "Psalm 46:1-10",
+			&models.MemoryVerse{Reference: "Psalm 46:10", Text: "He says, \"Be still, and know that I am God.\"", Citations: 2}},
+		{"ranges count for every verse", "We will not fear (vv. 1-2). Do not fear (v. 2).", "Psalm 46:1-10",
+			&models.MemoryVerse{Reference: "Psalm 46:2", Text: "Therefore we will not fear, though the earth give way.", Citations: 2}},
+		{"other books don't count", "All things work for good (Romans 8:28).", "Psalm 46:1-10",
+			&models.MemoryVerse{Reference: "Psalm 46:1", Text: "God is our refuge and strength, an ever-present help in trouble."}},
+		{"no reading", "Be still (v. 10).", "", nil},
+	}
+
+	for _, tt := range tests {
+		verses := parseVerses(passage, tt.reading)
+		citations := extractCitations(tt.body, tt.reading)
+		if got := MemoryVerse(verses, citations, tt.reading); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: MemoryVerse() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPullQuote(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		// A quote the author set apart wins over plain paragraphs
+		{"We all get tired on the road.\n\n> Faith is not a sprint.\n> It is a lifelong walk.", "Faith is not a sprint."},
+		// Emphasis marks are dropped
+		{"Rest is a gift. *God never asks you to earn His rest.*", "God never asks you to earn His rest."},
+		// Questions, citations and fragments are passed over
+		{"Why do you worry so much about tomorrow?\n\nHe is able (v. 3).\n\nso much more than we ask. God holds your tomorrow.", "God holds your tomorrow."},
+		{"Yes.", ""},
+	}
+
+	for _, tt := range tests {
+		if got := PullQuote(BodyBlocks(tt.body, "")); got != tt.want {
+			t.Errorf("PullQuote(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
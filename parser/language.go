@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages a devotional can be detected as
+const (
+	LanguageEnglish  = "en"
+	LanguageFilipino = "fil"
+	LanguageTaglish  = "taglish" // Filipino and English mixed
+)
+
+// Languages lists the supported language codes
+var Languages = []string{LanguageEnglish, LanguageFilipino, LanguageTaglish}
+
+// IsLanguage reports whether code is one of the supported language codes
+func IsLanguage(code string) bool {
+	for _, l := range Languages {
+		if l == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Common function words of each language. Words shared by both, like "at",
+// "may" and "is", are left out.
+var (
+	filipinoWords = wordSet("ang ng mga sa ay si ni kay nang na hindi ito iyan iyon ko mo niya natin namin ninyo nila " +
+		"ako ikaw siya tayo kami kayo sila akin atin amin kanya kaniya ating aming inyong kanilang " +
+		"kung para dahil pero kaya lamang lang din rin po ba pa upang tulad gaya")
+	englishWords = wordSet("the and of to that you we our your he his her they their it this these those " +
+		"with for from are was were be been have has will would can could should what when who which")
+)
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// detectLanguage classifies text as English, Filipino or Taglish by the
+// share of Filipino function words among the function words of both
+// languages. It returns "" when the text has too few words to tell.
+func detectLanguage(text string) string {
+	filipino, english := 0, 0
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '\''
+	})
+	for _, w := range words {
+		w = strings.Trim(w, "-'")
+		switch {
+		case filipinoWords[w]:
+			filipino++
+		case englishWords[w]:
+			english++
+		}
+	}
+
+	total := filipino + english
+	switch {
+	case total < 5:
+		return ""
+	case filipino*10 >= total*8:
+		return LanguageFilipino
+	case filipino*10 <= total*2:
+		return LanguageEnglish
+	}
+	return LanguageTaglish
+}
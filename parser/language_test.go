@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The Lord is my shepherd, and He leads us through the valley of the shadow of death.", LanguageEnglish},
+		{"Ang Panginoon ang aking pastol, hindi ako magkukulang. Siya ang nag-aakay sa akin sa mga pastulan.", LanguageFilipino},
+		{"Minsan, ang dami nating iniisip. But Paul reminds us na huwag mabalisa, and His peace will guard our hearts.", LanguageTaglish},
+		{"Amen.", ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectLanguage(tt.text); got != tt.want {
+			t.Errorf("detectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits on the messages the parser reads. The longest devotionals posted
+// are a few kilobytes over about 80 lines; anything past these is cut off.
+const (
+	MaxMessageBytes = 64 << 10
+	MaxMessageLines = 1000
+)
+
+// CheckLimits returns an error when a message is too large to parse whole
+func CheckLimits(msg string) error {
+	if len(msg) > MaxMessageBytes {
+		return fmt.Errorf("message is %d bytes, the limit is %d", len(msg), MaxMessageBytes)
+	}
+	if lines := strings.Count(msg, "\n") + 1; lines > MaxMessageLines {
+		return fmt.Errorf("message is %d lines, the limit is %d", lines, MaxMessageLines)
+	}
+	return nil
+}
+
+// truncate cuts a message down to the limits, at a line break when it has
+// too many lines and at a rune boundary when it has too many bytes
+func truncate(msg string) (string, bool) {
+	truncated := false
+	if len(msg) > MaxMessageBytes {
+		cut := MaxMessageBytes
+		for cut > 0 && !utf8.RuneStart(msg[cut]) {
+			cut--
+		}
+		msg, truncated = msg[:cut], true
+	}
+	if i := nthIndex(msg, '\n', MaxMessageLines); i >= 0 {
+		msg, truncated = msg[:i], true
+	}
+	return msg, truncated
+}
+
+// nthIndex returns the index of the nth occurrence of c in s, or -1
+func nthIndex(s string, c byte, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			if n--; n == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// cutPrefixFold returns s without prefix, matched ignoring case. It compares
+// rune by rune, so unlike slicing s by the length of a lowercased copy it is
+// safe on letters whose case mapping changes their length.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	rest := s
+	for _, p := range prefix {
+		r, size := utf8.DecodeRuneInString(rest)
+		if size == 0 || !strings.EqualFold(string(r), string(p)) {
+			return s, false
+		}
+		rest = rest[size:]
+	}
+	return rest, true
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCheckLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		ok   bool
+	}{
+		{"short", "Read Psalm 23", true},
+		{"at the byte limit", strings.Repeat("a", MaxMessageBytes), true},
+		{"over the byte limit", strings.Repeat("a", MaxMessageBytes+1), false},
+		{"at the line limit", strings.Repeat("a\n", MaxMessageLines-1) + "a", true},
+		{"over the line limit", strings.Repeat("a\n", MaxMessageLines), false},
+	}
+
+	for _, tt := range tests {
+		if err := CheckLimits(tt.msg); (err == nil) != tt.ok {
+			t.Errorf("%s: CheckLimits() = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	// A multibyte rune straddling the byte limit is dropped whole
+	msg := strings.Repeat("a", MaxMessageBytes-1) + "é and more"
+	got, truncated := truncate(msg)
+	if !truncated || len(got) != MaxMessageBytes-1 || !utf8.ValidString(got) {
+		t.Errorf("truncate() = %d bytes, truncated %v; want %d valid bytes", len(got), truncated, MaxMessageBytes-1)
+	}
+
+	got, truncated = truncate(strings.Repeat("line\n", MaxMessageLines+5))
+	if lines := strings.Count(got, "\n") + 1; !truncated || lines != MaxMessageLines {
+		t.Errorf("truncate() = %d lines, truncated %v; want %d", lines, truncated, MaxMessageLines)
+	}
+
+	if got, truncated := truncate("Read Psalm 23\n"); truncated || got != "Read Psalm 23\n" {
+		t.Errorf("truncate() = %q, %v; want the message unchanged", got, truncated)
+	}
+
+	result := Parse(strings.Repeat("\n", MaxMessageLines) + "Read Psalm 23")
+	if result.Devotional.Reading != "" || len(result.Warnings) == 0 || !strings.HasPrefix(result.Warnings[0], "message: truncated") {
+		t.Errorf("Parse() of a long message = reading %q, warnings %v; want it truncated", result.Devotional.Reading, result.Warnings)
+	}
+}
+
+func TestCutPrefixFold(t *testing.T) {
+	tests := []struct {
+		s, prefix string
+		want      string
+		ok        bool
+	}{
+		{"See Romans 8:28", "see ", "Romans 8:28", true},
+		{"CF. John 3:16", "cf. ", "John 3:16", true},
+		{"Romans 8:28", "see ", "Romans 8:28", false},
+		{"se", "see ", "se", false},
+		// The Kelvin sign lowercases to a one-byte "k" but is three bytes itself
+		{"Keep reading", "keep ", "reading", true},
+		{"İsaiah 41:10", "is", "İsaiah 41:10", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := cutPrefixFold(tt.s, tt.prefix)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("cutPrefixFold(%q, %q) = %q, %v; want %q, %v", tt.s, tt.prefix, got, ok, tt.want, tt.ok)
+		}
+	}
+}
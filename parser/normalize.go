@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"lwnra-devo-api/models"
+)
+
+// Mathematical alphanumeric symbols (U+1D400 to U+1D7FF) that Facebook users
+// paste to style text. Letters come in styles of 52 (A-Z, a-z), Greek letters
+// in styles of 58 and digits in styles of 10, in this order.
+const (
+	mathLetters = 0x1D400
+	mathGreek   = 0x1D6A8
+	mathDigits  = 0x1D7CE
+	mathEnd     = 0x1D800
+)
+
+var (
+	// letterStyles are the styles of the mathematical letters, in block order:
+	// bold, italic, bold italic, script, bold script, fraktur, double-struck,
+	// bold fraktur, sans-serif, sans-serif bold, sans-serif italic,
+	// sans-serif bold italic and monospace
+	letterStyles = []models.TextStyle{
+		{Bold: true}, {Italic: true}, {Bold: true, Italic: true}, {}, {Bold: true}, {}, {},
+		{Bold: true}, {}, {Bold: true}, {Italic: true}, {Bold: true, Italic: true}, {},
+	}
+	// greekStyles: bold, italic, bold italic, sans-serif bold, sans-serif bold italic
+	greekStyles = []models.TextStyle{{Bold: true}, {Italic: true}, {Bold: true, Italic: true}, {Bold: true}, {Bold: true, Italic: true}}
+	// digitStyles: bold, double-struck, sans-serif, sans-serif bold, monospace
+	digitStyles = []models.TextStyle{{Bold: true}, {}, {}, {Bold: true}, {}}
+
+	// letterlikeStyles are the letters missing from the mathematical block,
+	// which are encoded as letterlike symbols instead, e.g. italic h is U+210E
+	letterlikeStyles = map[rune]models.TextStyle{
+		'ℎ': {Italic: true},
+		'ℬ': {}, 'ℰ': {}, 'ℱ': {}, 'ℋ': {}, 'ℐ': {}, 'ℒ': {}, 'ℳ': {}, 'ℛ': {}, 'ℯ': {}, 'ℊ': {}, 'ℴ': {},
+		'ℭ': {}, 'ℌ': {}, 'ℑ': {}, 'ℜ': {}, 'ℨ': {},
+		'ℂ': {}, 'ℍ': {}, 'ℕ': {}, 'ℙ': {}, 'ℚ': {}, 'ℝ': {}, 'ℤ': {},
+	}
+
+	// punctuationReplacer standardizes quotes to ASCII, hyphens and en dashes
+	// to "-", em dashes to U+2014 and odd spaces to plain ones
+	punctuationReplacer = strings.NewReplacer(
+		"\u2018", "'", "\u2019", "'", "\u201a", "'", "\u201b", "'", "\u2032", "'",
+		"\u201c", "\"", "\u201d", "\"", "\u201e", "\"", "\u201f", "\"", "\u2033", "\"", "\u00ab", "\"", "\u00bb", "\"",
+		"\u2010", "-", "\u2011", "-", "\u2012", "-", "\u2013", "-", "\u2212", "-",
+		"\u2015", "\u2014", "\u2e3a", "\u2014",
+		"\u2026", "...",
+		"\u00a0", " ", "\u2009", " ", "\u202f", " ",
+		"\u200b", "", "\ufeff", "",
+	)
+)
+
+// NormalizeText maps styled Unicode letters and digits back to plain ones,
+// applies NFC normalization and standardizes quotes, dashes and spaces. Line
+// breaks are kept, so line numbers are the same before and after.
+func NormalizeText(s string) string {
+	text, _ := normalize(s)
+	return text
+}
+
+// normalize is NormalizeText that also reports whether the text used bold or
+// italic letters
+func normalize(s string) (string, models.TextStyle) {
+	var style models.TextStyle
+	if isASCII(s) {
+		return s, style
+	}
+	styled := strings.Map(func(r rune) rune {
+		plain, st, ok := plainRune(r)
+		if !ok {
+			return r
+		}
+		style.Bold = style.Bold || st.Bold
+		style.Italic = style.Italic || st.Italic
+		return plain
+	}, s)
+	return punctuationReplacer.Replace(norm.NFC.String(styled)), style
+}
+
+// normalizeLines splits a message into normalized lines and reports the
+// styling of each
+func normalizeLines(msg string) ([]string, []models.TextStyle) {
+	lines := strings.Split(msg, "\n")
+	styles := make([]models.TextStyle, len(lines))
+	for i, line := range lines {
+		lines[i], styles[i] = normalize(line)
+	}
+	return lines, styles
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// plainRune returns the plain letter or digit of a styled one and its style
+func plainRune(r rune) (rune, models.TextStyle, bool) {
+	var style models.TextStyle
+	switch {
+	case r < 0x210A: // below the letterlike symbols
+		return r, style, false
+	case r >= mathLetters && r < mathGreek:
+		if i := int(r-mathLetters) / 52; i < len(letterStyles) {
+			style = letterStyles[i]
+		}
+	case r >= mathGreek && r < mathDigits:
+		if i := int(r-mathGreek) / 58; i < len(greekStyles) {
+			style = greekStyles[i]
+		}
+	case r >= mathDigits && r < mathEnd:
+		style = digitStyles[int(r-mathDigits)/10]
+	default:
+		st, ok := letterlikeStyles[r]
+		if !ok {
+			return r, style, false
+		}
+		style = st
+	}
+
+	// Compatibility decomposition maps every one of them to a single plain rune
+	plain := []rune(norm.NFKC.String(string(r)))
+	if len(plain) != 1 || !(unicode.IsLetter(plain[0]) || unicode.IsDigit(plain[0])) {
+		return r, models.TextStyle{}, false
+	}
+	return plain[0], style, true
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		style models.TextStyle
+	}{
+		{"𝐖𝐀𝐋𝐊 𝐇𝐔𝐌𝐁𝐋𝐘", "WALK HUMBLY", models.TextStyle{Bold: true}},
+		{"𝑃𝑎𝑠𝑡𝑜𝑟 𝐵𝑒𝑛", "Pastor Ben", models.TextStyle{Italic: true}},
+		{"𝘄𝗮𝗹𝗸 𝘄𝗶𝘁𝗵 𝗚𝗼𝗱", "walk with God", models.TextStyle{Bold: true}},
+		{"𝙃𝙤𝙥𝙚", "Hope", models.TextStyle{Bold: true, Italic: true}},
+		{"𝚃𝚎𝚡𝚝 𝟏𝟐", "Text 12", models.TextStyle{Bold: true}},
+		{"ℎ𝑒𝑟𝑒", "here", models.TextStyle{Italic: true}},
+		{"“Don’t fear” – Isaiah 41:10 — really…", "\"Don't fear\" - Isaiah 41:10 — really...", models.TextStyle{}},
+		{"Cafe\u0301\u00a0time\u200b", "Caf\u00e9 time", models.TextStyle{}},
+		{"line one\nline two", "line one\nline two", models.TextStyle{}},
+	}
+
+	for _, tt := range tests {
+		got, style := normalize(tt.in)
+		if got != tt.want || style != tt.style {
+			t.Errorf("normalize(%q) = %q, %+v; want %q, %+v", tt.in, got, style, tt.want, tt.style)
+		}
+	}
+}
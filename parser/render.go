@@ -0,0 +1,412 @@
+package parser
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"lwnra-devo-api/models"
+)
+
+// markdownEscaper escapes the characters that would turn devotional text into
+// Markdown emphasis, code, links or HTML
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+// emphasisPattern matches text marked up as *bold* or _italic_. The marks
+// must hug the text, so "2 * 3 * 4" is left alone.
+var emphasisPattern = regexp.MustCompile(`\*([^\s*](?:[^*\n]*[^\s*])?)\*|_([^\s_](?:[^_\n]*[^\s_])?)_`)
+
+// blocks are the blank line separated blocks of a rendered devotional
+type blocks []string
+
+// add appends a block of the given lines, leaving out empty ones. Nothing is
+// added when all lines are empty.
+func (b *blocks) add(lines ...string) {
+	var kept []string
+	for _, line := range lines {
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) > 0 {
+		*b = append(*b, strings.Join(kept, "\n"))
+	}
+}
+
+// section appends a block of a header and its content, unless the content is
+// empty
+func (b *blocks) section(header, content string) {
+	if content != "" {
+		b.add(header, content)
+	}
+}
+
+func (b blocks) String() string {
+	return strings.Join(b, "\n\n")
+}
+
+// joinedFields are the pairs of adjacent fields Render puts in one block
+var joinedFields = map[[2]string]bool{
+	{FieldReading, FieldDate}:    true,
+	{FieldVersion, FieldPassage}: true,
+	{FieldTitle, FieldAuthor}:    true,
+}
+
+// Render lays a devotional out as a Facebook post in the layout of its
+// template, with styled fields in Unicode bold or italic letters. Parsing the
+// post gives the devotional back.
+func Render(devo models.Devotional) string {
+	t := lookupTemplate(devo.Template)
+	styled := func(field, text string) string {
+		return stylize(text, devo.Styles[field])
+	}
+
+	// Hashtags closing the prayer, or the body when there is none, are not
+	// part of it, so they are put back at its end
+	body, prayer := devo.Body, devo.Prayer
+	if tags := missingHashtags(devo); len(tags) > 0 {
+		if prayer != "" {
+			prayer += " #" + strings.Join(tags, " #")
+		} else if body != "" {
+			body += " #" + strings.Join(tags, " #")
+		}
+	}
+
+	author := devo.Author
+	if prefixes := t.Rules.Author.StripPrefixes; author != "" && len(prefixes) > 0 {
+		author = strings.TrimSpace(prefixes[0]) + " " + author
+	}
+
+	// Fields are laid out in the template's order; the date follows the
+	// reading, the passage its version line and the author the title in the
+	// same block
+	var post blocks
+	post.add(t.banner())
+	var block []string
+	for i, field := range t.Order {
+		if i > 0 && !joinedFields[[2]string{t.Order[i-1], field}] {
+			post.add(block...)
+			block = nil
+		}
+		switch field {
+		case FieldReading:
+			if devo.Reading != "" {
+				block = append(block, headerOr(t.Headers.Reading, "Read")+" "+styled(FieldReading, devo.Reading))
+			}
+		case FieldDate:
+			block = append(block, styled(FieldDate, devo.Date))
+		case FieldVersion:
+			if devo.Passage != "" && devo.Version != "" {
+				block = append(block, styled(FieldVersion, strings.TrimSpace(devo.Reading+" "+devo.Version)))
+			}
+		case FieldPassage:
+			block = append(block, styled(FieldPassage, devo.Passage))
+		case FieldReflectionQs:
+			// The header is kept without questions, it is what ends the passage
+			block = append(block, headerOr(t.Headers.Questions, "REFLECTION QUESTIONS"), styled(FieldReflectionQs, strings.Join(devo.ReflectionQs, "\n")))
+		case FieldTitle:
+			block = append(block, styled(FieldTitle, devo.Title))
+		case FieldAuthor:
+			block = append(block, styled(FieldAuthor, author))
+		case FieldBody:
+			if len(devo.BodyBlocks) > 0 && devo.Styles[FieldBody] != (models.TextStyle{}) {
+				block = append(block, stylizeBlocks(body, devo))
+			} else {
+				block = append(block, styled(FieldBody, body))
+			}
+		case FieldPrayer:
+			if prayer != "" {
+				block = append(block, headerOr(t.Headers.Prayer, "PRAYER"), styled(FieldPrayer, prayer))
+			}
+		}
+	}
+	post.add(block...)
+	post.add(devo.Footer)
+	return post.String()
+}
+
+// stylizeBlocks styles the lines of a body in the style of their block, as
+// the body may be styled in places only
+func stylizeBlocks(body string, devo models.Devotional) string {
+	lines := strings.Split(body, "\n")
+	_, lineBlock := splitBody(lines, nil, devo.Reading)
+	for i, b := range lineBlock {
+		if b >= 0 && b < len(devo.BodyBlocks) {
+			lines[i] = stylize(lines[i], devo.BodyBlocks[b].TextStyle)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderMarkdown lays a devotional out as Markdown: the title as a heading,
+// the author, date and reading, the passage as a quote, the questions as a
+// list, then the body, prayer and footer
+func RenderMarkdown(devo models.Devotional) string {
+	t := lookupTemplate(devo.Template)
+
+	var doc blocks
+	if devo.Title != "" {
+		doc.add("# " + markdownEscaper.Replace(devo.Title))
+	}
+	if devo.Author != "" {
+		doc.add("_" + markdownEscaper.Replace(devo.Author) + "_")
+	}
+	doc.add(devo.Date)
+	if reading := readingLine(t, devo); reading != "" {
+		doc.add("**" + markdownEscaper.Replace(reading) + "**")
+	}
+	if devo.Passage != "" {
+		var quote []string
+		for i, line := range strings.Split(devo.Passage, "\n") {
+			if i > 0 {
+				quote = append(quote, ">")
+			}
+			quote = append(quote, strings.TrimSpace("> "+markdownEscaper.Replace(line)))
+		}
+		doc.add(quote...)
+	}
+	if len(devo.ReflectionQs) > 0 {
+		doc.add("## " + headerOr(t.Headers.Questions, "REFLECTION QUESTIONS"))
+		var list []string
+		for _, q := range devo.ReflectionQs {
+			list = append(list, "- "+markdownEscaper.Replace(q))
+		}
+		doc.add(list...)
+	}
+	doc.add(markdownText(devo.Body))
+	if devo.Prayer != "" {
+		doc.add("## " + headerOr(t.Headers.Prayer, "PRAYER"))
+		doc.add(markdownText(devo.Prayer))
+	}
+	doc.add(markdownText(devo.Footer))
+	return doc.String()
+}
+
+// markdownText escapes text for Markdown and keeps its line breaks, which
+// Markdown would otherwise join into one paragraph
+func markdownText(text string) string {
+	lines := strings.Split(markdownEscaper.Replace(text), "\n")
+	for i := 0; i < len(lines)-1; i++ {
+		if strings.TrimSpace(lines[i]) != "" && strings.TrimSpace(lines[i+1]) != "" {
+			lines[i] += `\`
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderText lays a devotional out as plain text, in the order of
+// RenderMarkdown
+func RenderText(devo models.Devotional) string {
+	t := lookupTemplate(devo.Template)
+
+	var doc blocks
+	doc.add(devo.Title, devo.Author, devo.Date)
+	doc.add(readingLine(t, devo), devo.Passage)
+	doc.section(headerOr(t.Headers.Questions, "REFLECTION QUESTIONS"), strings.Join(devo.ReflectionQs, "\n"))
+	doc.add(devo.Body)
+	doc.section(headerOr(t.Headers.Prayer, "PRAYER"), devo.Prayer)
+	doc.add(devo.Footer)
+	return doc.String()
+}
+
+// readingLine introduces the reading the way the template does, with the
+// version in parentheses, e.g. "Read Matthew 6:16-18 (NIV)"
+func readingLine(t *Template, devo models.Devotional) string {
+	if devo.Reading == "" {
+		return ""
+	}
+	line := headerOr(t.Headers.Reading, "Read") + " " + devo.Reading
+	if devo.Version != "" {
+		line += " (" + devo.Version + ")"
+	}
+	return line
+}
+
+// banner returns the banner a post of the template starts with, preferring
+// one that is also a marker of the template
+func (t *Template) banner() string {
+	for _, banner := range t.Headers.Banner {
+		for _, marker := range t.Markers {
+			if strings.Contains(banner, marker) {
+				return banner
+			}
+		}
+	}
+	return headerOr(t.Headers.Banner, "")
+}
+
+// headerOr returns the first of the headers, or fallback when there are none
+func headerOr(headers []string, fallback string) string {
+	if len(headers) == 0 {
+		return fallback
+	}
+	return headers[0]
+}
+
+// missingHashtags returns the hashtags of a devotional that are not in any
+// of its fields, which are the ones the parser strips from the end of the
+// prayer or body
+func missingHashtags(devo models.Devotional) []string {
+	fields := append([]string{devo.Reading, devo.Passage, devo.Title, devo.Author, devo.Body, devo.Prayer, devo.Footer}, devo.ReflectionQs...)
+	found, _, _ := extractTags(fields)
+
+	var missing []string
+	for _, tag := range devo.Hashtags {
+		if !contains(found, tag) {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// stylize writes the letters and digits of text in the Unicode mathematical
+// letters of the style, the inverse of normalize
+func stylize(text string, style models.TextStyle) string {
+	var upper, lower, digit rune
+	switch {
+	case style.Bold && style.Italic:
+		upper, lower, digit = mathLetters+104, mathLetters+130, mathDigits
+	case style.Bold:
+		upper, lower, digit = mathLetters, mathLetters+26, mathDigits
+	case style.Italic:
+		upper, lower = mathLetters+52, mathLetters+78
+	default:
+		return text
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			return upper + r - 'A'
+		case r == 'h' && style.Italic && !style.Bold:
+			return 'ℎ' // italic h is a letterlike symbol
+		case r >= 'a' && r <= 'z':
+			return lower + r - 'a'
+		case r >= '0' && r <= '9' && digit != 0:
+			return digit + r - '0'
+		}
+		return r
+	}, text)
+}
+
+// RenderBodyHTML renders body blocks as HTML. The text is escaped, so the
+// only markup is the paragraphs, quotes, lists, line breaks and emphasis the
+// blocks describe.
+func RenderBodyHTML(body []models.BodyBlock) string {
+	var out []string
+	for i, block := range body {
+		text := emphasize(block.Text, html.EscapeString, "<strong>", "</strong>", "<em>", "</em>")
+		text = strings.ReplaceAll(text, "\n", "<br>\n")
+		text = styleHTML(text, block.TextStyle)
+		switch block.Type {
+		case BlockListItem:
+			item := "<li>" + text + "</li>"
+			if i == 0 || body[i-1].Type != BlockListItem {
+				item = "<ul>\n" + item
+			}
+			if i == len(body)-1 || body[i+1].Type != BlockListItem {
+				item += "\n</ul>"
+			}
+			out = append(out, item)
+		case BlockQuote:
+			out = append(out, "<blockquote><p>"+text+"</p></blockquote>")
+		case BlockScripture:
+			out = append(out, `<blockquote class="scripture"><p>`+text+"</p><cite>"+html.EscapeString(block.Reference)+"</cite></blockquote>")
+		default:
+			out = append(out, "<p>"+text+"</p>")
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// styleHTML wraps a block posted in styled letters in bold or italic tags
+func styleHTML(text string, style models.TextStyle) string {
+	if style.Italic {
+		text = "<em>" + text + "</em>"
+	}
+	if style.Bold {
+		text = "<strong>" + text + "</strong>"
+	}
+	return text
+}
+
+// RenderBodyMarkdown renders body blocks as Markdown, with quotes and scripture
+// quotes as block quotes and list items as a list
+func RenderBodyMarkdown(body []models.BodyBlock) string {
+	var doc blocks
+	for i, block := range body {
+		text := emphasize(block.Text, markdownEscaper.Replace, "**", "**", "_", "_")
+		text = styleMarkdown(text, block.TextStyle)
+		lines := strings.Split(text, "\n")
+		switch block.Type {
+		case BlockListItem:
+			item := "- " + strings.Join(lines, "\\\n  ")
+			if i > 0 && body[i-1].Type == BlockListItem {
+				doc[len(doc)-1] += "\n" + item
+				continue
+			}
+			doc.add(item)
+		case BlockQuote, BlockScripture:
+			for j, line := range lines {
+				lines[j] = "> " + line
+			}
+			quote := strings.Join(lines, "\\\n")
+			if block.Reference != "" {
+				quote += "\n>\n> \u2014 " + markdownEscaper.Replace(block.Reference)
+			}
+			doc.add(quote)
+		default:
+			doc.add(strings.Join(lines, "\\\n"))
+		}
+	}
+	return doc.String()
+}
+
+// styleMarkdown marks up a block posted in styled letters as bold or italic
+func styleMarkdown(text string, style models.TextStyle) string {
+	if text == "" {
+		return text
+	}
+	if style.Italic {
+		text = "_" + text + "_"
+	}
+	if style.Bold {
+		text = "**" + text + "**"
+	}
+	return text
+}
+
+// emphasize escapes text and replaces its *bold* and _italic_ markup with the
+// given tags
+func emphasize(text string, escape func(string) string, boldOpen, boldClose, italicOpen, italicClose string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range emphasisPattern.FindAllStringSubmatchIndex(text, -1) {
+		// Marks inside words, as in snake_case_names, are not emphasis
+		if (loc[0] > 0 && isWordByte(text[loc[0]-1])) || (loc[1] < len(text) && isWordByte(text[loc[1]])) {
+			continue
+		}
+		out.WriteString(escape(text[last:loc[0]]))
+		if loc[2] >= 0 {
+			out.WriteString(boldOpen + escape(text[loc[2]:loc[3]]) + boldClose)
+		} else {
+			out.WriteString(italicOpen + escape(text[loc[4]:loc[5]]) + italicClose)
+		}
+		last = loc[1]
+	}
+	out.WriteString(escape(text[last:]))
+	return out.String()
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
+}
@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"lwnra-devo-api/models"
+)
+
+// TestRenderRoundTrip renders every post of the corpus and checks that the
+// rendered post parses to the same devotional
+func TestRenderRoundTrip(t *testing.T) {
+	var posts []string
+	for _, dir := range []string{"posts", "templates"} {
+		files, err := filepath.Glob(filepath.Join("testdata", dir, "*.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		posts = append(posts, files...)
+	}
+
+	for _, post := range posts {
+		t.Run(filepath.Base(post), func(t *testing.T) {
+			msg, err := os.ReadFile(post)
+			if err != nil {
+				t.Fatal(err)
+			}
+			devo := ParseDevotional(string(msg))
+			rendered := Render(devo)
+			for _, d := range DiffDevotionals(devo, ParseDevotional(rendered)) {
+				t.Errorf("%s:\n want: %v\n  got: %v", d.Field, d.Want, d.Got)
+			}
+			if t.Failed() {
+				t.Logf("rendered post:\n%s", rendered)
+			}
+		})
+	}
+}
+
+// generatedDevotional is a random devotional in the layout of one of the
+// registered templates
+type generatedDevotional struct {
+	models.Devotional
+}
+
+var (
+	generatedBooks     = []string{"Matthew", "John", "Romans", "Psalm", "Isaiah", "James", "Philippians"}
+	generatedVersions  = []string{"NIV", "ESV", "NLT", "NKJV", "KJV", "MBB"}
+	generatedSentences = []string{
+		"Trust in the Lord with all your heart.",
+		"He does not promise an easy road, but He promises to walk it with us.",
+		"Grace is not earned, it is received (v. 5).",
+		"Our hope rests on what God has already done.",
+		"Every season has its purpose, even the hard ones.",
+		"Wait for the Lord and be strong.",
+	}
+	generatedQuestions = []string{
+		"Where do you need to trust God today?",
+		"What is keeping you from resting in His promises?",
+		"Who can you encourage this week?",
+	}
+	generatedTitles  = []string{"WAITING ON GOD", "GRACE UPON GRACE", "A LAMP TO MY FEET", "STRENGTH FOR TODAY"}
+	generatedAuthors = []string{"Pastor Ben Villanueva", "Grace Dela Cruz", "Ptr. Mark Santos"}
+	generatedPrayers = []string{
+		"Lord, teach me to trust You more. Amen.",
+		"Father, thank You for Your faithfulness. Help me to rest in You. Amen.",
+	}
+	generatedStyles = []models.TextStyle{{}, {Bold: true}, {Italic: true}, {Bold: true, Italic: true}}
+)
+
+// Generate implements quick.Generator
+func (generatedDevotional) Generate(r *rand.Rand, size int) reflect.Value {
+	pick := func(list []string) string { return list[r.Intn(len(list))] }
+	templates := Templates()
+	t := templates[r.Intn(len(templates))]
+
+	devo := models.Devotional{Template: t.Name}
+	devo.Date = time.Date(2024, time.January, 1+r.Intn(900), 0, 0, 0, 0, time.UTC).Format(displayDateLayout)
+
+	chapter, first, count := 1+r.Intn(20), 1+r.Intn(20), 1+r.Intn(4)
+	devo.Reading = fmt.Sprintf("%s %d:%d", pick(generatedBooks), chapter, first)
+	if count > 1 {
+		devo.Reading += fmt.Sprintf("-%d", first+count-1)
+	}
+	var passage []string
+	for n := first; n < first+count; n++ {
+		passage = append(passage, fmt.Sprintf("%d %s", n, pick(generatedSentences)))
+	}
+	devo.Passage = strings.Join(passage, "\n")
+	if t.has[FieldVersion] {
+		devo.Version = pick(generatedVersions)
+	}
+
+	if t.has[FieldReflectionQs] {
+		for i := r.Intn(4); i > 0; i-- {
+			devo.ReflectionQs = append(devo.ReflectionQs, pick(generatedQuestions))
+		}
+	}
+	devo.Title = pick(generatedTitles)
+	devo.Author = pick(generatedAuthors)
+	var paragraphs []string
+	for i := 1 + r.Intn(3); i > 0; i-- {
+		paragraphs = append(paragraphs, pick(generatedSentences)+" "+pick(generatedSentences))
+	}
+	devo.Body = strings.Join(paragraphs, "\n\n")
+	devo.Prayer = pick(generatedPrayers)
+
+	for _, field := range []string{FieldTitle, FieldAuthor, FieldBody, FieldPrayer} {
+		if style := generatedStyles[r.Intn(len(generatedStyles))]; style != (models.TextStyle{}) {
+			if devo.Styles == nil {
+				devo.Styles = make(map[string]models.TextStyle)
+			}
+			devo.Styles[field] = style
+		}
+	}
+	return reflect.ValueOf(generatedDevotional{devo})
+}
+
+// TestRenderParseProperty checks that a rendered devotional parses back to
+// its fields, and that rendering a parsed devotional gives it back whole
+func TestRenderParseProperty(t *testing.T) {
+	property := func(g generatedDevotional) bool {
+		want := g.Devotional
+		got := ParseDevotional(Render(want))
+		if got.Date != want.Date || got.Reading != want.Reading || got.Version != want.Version ||
+			got.Passage != want.Passage || !reflect.DeepEqual(got.ReflectionQs, want.ReflectionQs) ||
+			got.Title != want.Title || got.Author != want.Author || got.Body != want.Body ||
+			got.Prayer != want.Prayer || got.Template != want.Template || !reflect.DeepEqual(got.Styles, want.Styles) {
+			t.Logf("rendered post:\n%s\nparsed: %+v", Render(want), got)
+			return false
+		}
+		return len(DiffDevotionals(got, ParseDevotional(Render(got)))) == 0
+	}
+	config := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(property, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	devo := models.Devotional{
+		Date:         "August 2, 2025",
+		Reading:      "Matthew 6:16-18",
+		Version:      "NIV",
+		Passage:      "16 When you fast...\n17 But when you fast...",
+		ReflectionQs: []string{"What do you do *for* others to notice?"},
+		Title:        "FASTING IN SECRET",
+		Author:       "Pastor Ben Villanueva",
+		Body:         "First paragraph.\n\nSecond paragraph.",
+		Prayer:       "Lord, see my heart. Amen.",
+		Footer:       "Share this!\n#DailyDevotional",
+		Template:     "standard",
+	}
+	want := `# FASTING IN SECRET
+
+_Pastor Ben Villanueva_
+
+August 2, 2025
+
+**Read Matthew 6:16-18 (NIV)**
+
+> 16 When you fast...
+>
+> 17 But when you fast...
+
+## REFLECTION QUESTIONS
+
+- What do you do \*for\* others to notice?
+
+First paragraph.
+
+Second paragraph.
+
+## PRAYER
+
+Lord, see my heart. Amen.
+
+Share this!\
+#DailyDevotional`
+	if got := RenderMarkdown(devo); got != want {
+		t.Errorf("RenderMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
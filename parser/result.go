@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+// Field names used as keys of ParseResult.Fields
+const (
+	FieldDate         = "date"
+	FieldReading      = "reading"
+	FieldVersion      = "version"
+	FieldPassage      = "passage"
+	FieldReflectionQs = "reflection_qs"
+	FieldTitle        = "title"
+	FieldAuthor       = "author"
+	FieldBody         = "body"
+	FieldPrayer       = "prayer"
+)
+
+// Span is a 1-based, inclusive range of lines in the parsed message
+type Span struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// FieldResult describes how a single devotional field was extracted
+type FieldResult struct {
+	Value      interface{} `json:"value"`
+	Span       *Span       `json:"span,omitempty"` // nil when the field was not found
+	Confidence float64     `json:"confidence"`     // 0 (not found) to 1 (certain)
+	Warnings   []string    `json:"warnings,omitempty"`
+}
+
+// ParseResult is the detailed outcome of parsing a devotional post. It lets
+// callers tell a field that is legitimately empty apart from a parse failure.
+type ParseResult struct {
+	Devotional models.Devotional       `json:"devotional"`
+	Template   string                  `json:"template"`            // name of the template the post was parsed with
+	DateTime   *time.Time              `json:"date_time,omitempty"` // the date as a time, midnight UTC; nil without a date
+	Fields     map[string]*FieldResult `json:"fields"`
+	Warnings   []string                `json:"warnings"` // all field warnings, prefixed with the field name
+}
+
+// Parse parses a Facebook post message and reports, for every field, the
+// extracted value, the lines it came from, a confidence score and warnings
+func Parse(msg string) ParseResult {
+	m := detect(msg)
+	devo := m.devo
+
+	result := ParseResult{
+		Devotional: devo,
+		Template:   m.template.Name,
+		Fields:     make(map[string]*FieldResult),
+		Warnings:   []string{},
+	}
+
+	// Reading
+	reading := newField(devo.Reading, m.reading.span())
+	switch {
+	case devo.Reading == "":
+		reading.fail("no \"Read\" line found")
+	default:
+		if _, err := scripture.Parse(devo.Reading); err != nil {
+			reading.lower(0.5, "reading does not look like a scripture reference: "+err.Error())
+		}
+	}
+	result.Fields[FieldReading] = reading
+
+	// Date
+	date := newField(devo.Date, m.date.span())
+	switch {
+	case devo.Date == "":
+		date.fail("no date found")
+	case !m.dateAfterRead:
+		date.lower(0.8, "date not found right after the \"Read\" line")
+	}
+	if w := m.dateValue.weekdayWarning(); w != "" {
+		date.lower(0.7, w)
+	}
+	if devo.Date != "" {
+		result.DateTime = &m.dateValue.Time
+	}
+	result.Fields[FieldDate] = date
+
+	// Version
+	version := newField(devo.Version, m.version.span())
+	switch {
+	case devo.Version == "":
+		version.fail("no version found after the reading reference")
+	case devo.VersionInfo == nil:
+		version.lower(0.5, "unknown version "+devo.Version)
+	}
+	result.Fields[FieldVersion] = version
+
+	// Passage
+	passage := newField(devo.Passage, m.passage.span())
+	switch {
+	case devo.Passage == "":
+		passage.fail("no passage verses found")
+	case devo.Reading == "":
+		passage.lower(0.6, "passage found without a \"Read\" line")
+	}
+	for _, w := range versesOutsideReading(devo.Verses, devo.Reading) {
+		passage.lower(0.7, w)
+	}
+	result.Fields[FieldPassage] = passage
+
+	// Reflection questions
+	questions := newField(devo.ReflectionQs, m.questions.span())
+	switch {
+	case !m.questionsHeader.found():
+		questions.fail("no REFLECTION QUESTIONS header found")
+	case len(devo.ReflectionQs) == 0:
+		questions.fail("REFLECTION QUESTIONS header has no questions")
+	}
+	result.Fields[FieldReflectionQs] = questions
+
+	// Title
+	title := newField(devo.Title, m.title.span())
+	switch {
+	case devo.Title == "":
+		title.fail("no all-caps title found")
+	case m.titleFromFallback:
+		title.lower(0.6, "title not found after the reflection questions")
+	}
+	result.Fields[FieldTitle] = title
+
+	// Author
+	author := newField(devo.Author, m.author.span())
+	switch {
+	case devo.Author == "":
+		author.fail("no author line after the title")
+	case len(devo.Author) > 60 || strings.HasSuffix(devo.Author, "."):
+		author.lower(0.4, "author line looks like body text")
+	}
+	result.Fields[FieldAuthor] = author
+
+	// Body
+	body := newField(devo.Body, m.body.span())
+	switch {
+	case devo.Body == "":
+		body.fail("no body found between the author and the prayer")
+	case !m.prayerHeader.found():
+		body.lower(0.6, "no PRAYER header found, body runs to the end of the post")
+	}
+	result.Fields[FieldBody] = body
+
+	// Prayer
+	prayer := newField(devo.Prayer, m.prayer.span())
+	switch {
+	case !m.prayerHeader.found():
+		prayer.fail("no PRAYER header found")
+	case devo.Prayer == "":
+		prayer.fail("PRAYER header has no text")
+	}
+	result.Fields[FieldPrayer] = prayer
+
+	if m.truncated {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("message: truncated to %d bytes and %d lines", MaxMessageBytes, MaxMessageLines))
+	}
+	for _, name := range []string{FieldDate, FieldReading, FieldVersion, FieldPassage, FieldReflectionQs, FieldTitle, FieldAuthor, FieldBody, FieldPrayer} {
+		for _, w := range result.Fields[name].Warnings {
+			result.Warnings = append(result.Warnings, name+": "+w)
+		}
+	}
+
+	return result
+}
+
+// newField creates a field result, fully confident if a value was found
+func newField(value interface{}, span *Span) *FieldResult {
+	return &FieldResult{Value: value, Span: span, Confidence: 1}
+}
+
+// fail marks the field as not found
+func (f *FieldResult) fail(warning string) {
+	f.Confidence = 0
+	f.Span = nil
+	f.Warnings = append(f.Warnings, warning)
+}
+
+// lower caps the confidence of the field and records an optional warning
+func (f *FieldResult) lower(confidence float64, warning string) {
+	if confidence < f.Confidence {
+		f.Confidence = confidence
+	}
+	if warning != "" {
+		f.Warnings = append(f.Warnings, warning)
+	}
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"lwnra-devo-api/models"
+)
+
+// numberWords are the spelled-out part numbers, in English and Filipino
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"isa": 1, "dalawa": 2, "tatlo": 3, "apat": 4, "lima": 5, "anim": 6, "pito": 7, "walo": 8, "siyam": 9, "sampu": 10,
+}
+
+var (
+	// partSuffixPattern matches a part marker closing a title, which follows
+	// the series name: "FRUIT OF THE SPIRIT (PART 2 OF 5)", "HOPE, Part 2"
+	partSuffixPattern = regexp.MustCompile(`(?i)^(.*?)[\s,:(\-\x{2014}]*\b((?:part|pt\.?|bahagi)\s+(\d{1,2}|[a-z]+)(?:\s+(?:of|ng)\s+(\d{1,2}|[a-z]+))?)\)?$`)
+
+	// markerPrefixPattern matches a marker opening a title, which is followed
+	// by the day's topic: "Week 3: Patience", "Day 4 of 7 - Joy"
+	markerPrefixPattern = regexp.MustCompile(`(?i)^((?:part|pt\.?|bahagi|week|day|linggo|araw)\s+(\d{1,2}|[a-z]+)(?:\s+(?:of|ng)\s+(\d{1,2}|[a-z]+))?)\s*[:\-\x{2014}]\s*\S`)
+
+	// bodyPartPattern finds a part marker in a body: "part 2 of 5", "day 4 of 7"
+	bodyPartPattern = regexp.MustCompile(`(?i)\b((?:part|bahagi)\s+(\d{1,2}|[a-z]+)(?:\s+(?:of|ng)\s+(\d{1,2}|[a-z]+))?|(?:week|day)\s+(\d{1,2}|[a-z]+)\s+of\s+(\d{1,2}|[a-z]+))\b`)
+
+	// seriesNamePattern finds a series named in a body: `our series on "Fruit
+	// of the Spirit"`, "Series: Fruit of the Spirit"
+	seriesNamePattern = regexp.MustCompile(`(?i)\bseries(?:\s+(?:on|about|called|titled|entitled)|:)\s+["']?([^"'.,;:!?\n]{3,60})`)
+)
+
+// partNumber returns the number of a part written in digits or words, or 0
+func partNumber(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return numberWords[strings.ToLower(s)]
+}
+
+// extractSeries finds a devotional's place in a series from its title, or
+// its body when the title has no marker, and the series name when the post
+// gives one. It returns nil for devotionals outside a series.
+func extractSeries(title, body string) *models.SeriesMarker {
+	// Most bodies name no part or series; skip the patterns for them
+	lower := strings.ToLower(body)
+	bodyHasPart := strings.Contains(lower, "part") || strings.Contains(lower, "bahagi") || strings.Contains(lower, "week") || strings.Contains(lower, "day")
+	bodyHasSeries := strings.Contains(lower, "series")
+
+	var marker models.SeriesMarker
+	if m := partSuffixPattern.FindStringSubmatch(title); m != nil && partNumber(m[3]) > 0 {
+		marker = models.SeriesMarker{Text: m[2], Title: strings.Trim(m[1], " ,:(-—"), Part: partNumber(m[3]), Of: partNumber(m[4])}
+	} else if m := markerPrefixPattern.FindStringSubmatch(title); m != nil && partNumber(m[2]) > 0 {
+		marker = models.SeriesMarker{Text: m[1], Part: partNumber(m[2]), Of: partNumber(m[3])}
+	} else if bodyHasPart {
+		for _, m := range bodyPartPattern.FindAllStringSubmatch(body, -1) {
+			part, of := partNumber(m[2]), partNumber(m[3])
+			if m[4] != "" {
+				// "day three of creation" is no series, "day 3 of 7" is
+				part, of = partNumber(m[4]), partNumber(m[5])
+				if of == 0 {
+					continue
+				}
+			}
+			if part > 0 {
+				marker = models.SeriesMarker{Text: m[1], Part: part, Of: of}
+				break
+			}
+		}
+	}
+
+	if marker.Title == "" && bodyHasSeries {
+		if m := seriesNamePattern.FindStringSubmatch(body); m != nil {
+			marker.Title = strings.TrimSpace(m[1])
+			if marker.Text == "" {
+				marker.Text = strings.TrimSpace(m[0])
+			}
+		}
+	}
+	if marker.Text == "" {
+		return nil
+	}
+	return &marker
+}
@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"lwnra-devo-api/models"
+)
+
+func TestExtractSeries(t *testing.T) {
+	tests := []struct {
+		title, body string
+		want        *models.SeriesMarker
+	}{
+		{"FRUIT OF THE SPIRIT (PART 2 OF 5)", "", &models.SeriesMarker{Text: "PART 2 OF 5", Title: "FRUIT OF THE SPIRIT", Part: 2, Of: 5}},
+		{"HOPE THAT HOLDS, Part Three", "", &models.SeriesMarker{Text: "Part Three", Title: "HOPE THAT HOLDS", Part: 3}},
+		{"ANG MABUTING PASTOL - BAHAGI 2", "", &models.SeriesMarker{Text: "BAHAGI 2", Title: "ANG MABUTING PASTOL", Part: 2}},
+		{"WEEK 3: PATIENCE", "", &models.SeriesMarker{Text: "WEEK 3", Part: 3}},
+		{"DAY 4 OF 7 - JOY", "", &models.SeriesMarker{Text: "DAY 4 OF 7", Part: 4, Of: 7}},
+		{"WEEK 3: PATIENCE", `This week in our series on "Fruit of the Spirit" we look at patience.`,
+			&models.SeriesMarker{Text: "WEEK 3", Title: "Fruit of the Spirit", Part: 3}},
+		{"LOVE IS PATIENT", "Welcome to part 2 of 5 of our study. Love waits.", &models.SeriesMarker{Text: "part 2 of 5", Part: 2, Of: 5}},
+		{"LOVE IS PATIENT", "Series: Fruit of the Spirit. Love waits.", &models.SeriesMarker{Text: "Series: Fruit of the Spirit", Title: "Fruit of the Spirit"}},
+		// Not series markers
+		{"THE BETTER PART", "", nil},
+		{"IN THE BEGINNING", "On day three of creation, God gathered the waters. It is part of His plan.", nil},
+	}
+
+	for _, tt := range tests {
+		if got := extractSeries(tt.title, tt.body); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("extractSeries(%q, %q) = %+v, want %+v", tt.title, tt.body, got, tt.want)
+		}
+	}
+}
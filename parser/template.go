@@ -0,0 +1,291 @@
+package parser
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultTemplates is the built-in template configuration
+//
+//go:embed templates.json
+var defaultTemplates []byte
+
+// Template describes the layout of one kind of devotional post
+type Template struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Markers     []string `json:"markers,omitempty"` // text that identifies posts using this template
+	Headers     struct {
+		Banner    []string `json:"banner,omitempty"`    // post banner, e.g. "DAILY DEVOTIONAL"
+		Reading   []string `json:"reading,omitempty"`   // line prefixes introducing the reading reference
+		Questions []string `json:"questions,omitempty"` // reflection questions headers
+		Prayer    []string `json:"prayer,omitempty"`    // prayer headers
+	} `json:"headers"`
+	Order []string      `json:"order"` // the fields of the layout, in the order they appear
+	Rules TemplateRules `json:"rules"`
+
+	has map[string]bool
+	pos map[string]int // place of each field in Order
+}
+
+// TemplateRules are the field rules of a template
+type TemplateRules struct {
+	Title struct {
+		MinLength int      `json:"min_length,omitempty"`
+		Exclude   []string `json:"exclude,omitempty"` // all-caps lines containing these are never the title
+	} `json:"title"`
+	Author struct {
+		StripPrefixes []string `json:"strip_prefixes,omitempty"` // e.g. "Guest Writer:"
+	} `json:"author"`
+}
+
+// validate checks the template and prepares it for parsing
+func (t *Template) validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("template has no name")
+	}
+
+	t.has = make(map[string]bool, len(t.Order))
+	t.pos = make(map[string]int, len(t.Order))
+	for i, field := range t.Order {
+		if _, ok := fieldSections[field]; !ok {
+			return fmt.Errorf("template %q: unknown section %q", t.Name, field)
+		}
+		if t.has[field] {
+			return fmt.Errorf("template %q: section %q is listed twice", t.Name, field)
+		}
+		t.has[field] = true
+		t.pos[field] = i
+	}
+
+	switch {
+	case !t.has[FieldTitle] || !t.has[FieldBody]:
+		return fmt.Errorf("template %q: order must include title and body", t.Name)
+	case t.has[FieldReflectionQs] && len(t.Headers.Questions) == 0:
+		return fmt.Errorf("template %q: reflection_qs needs a questions header", t.Name)
+	case t.has[FieldPrayer] && len(t.Headers.Prayer) == 0:
+		return fmt.Errorf("template %q: prayer needs a prayer header", t.Name)
+	}
+	if t.Rules.Title.MinLength == 0 {
+		t.Rules.Title.MinLength = 6
+	}
+	return nil
+}
+
+// readingPrefix returns the reading prefix the line starts with, if any
+func (t *Template) readingPrefix(line string) (string, bool) {
+	for _, prefix := range t.Headers.Reading {
+		if strings.HasPrefix(line, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// isQuestionsHeader reports whether the upper-cased line is a reflection
+// questions header
+func (t *Template) isQuestionsHeader(upper string) bool {
+	if !t.has[FieldReflectionQs] {
+		return false
+	}
+	for _, header := range t.Headers.Questions {
+		if strings.Contains(upper, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrayerHeader reports whether the line is a prayer header
+func (t *Template) isPrayerHeader(line string) bool {
+	if !t.has[FieldPrayer] {
+		return false
+	}
+	for _, header := range t.Headers.Prayer {
+		if strings.HasPrefix(line, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBanner reports whether the line contains the post banner
+func (t *Template) isBanner(line string) bool {
+	for _, banner := range t.Headers.Banner {
+		if strings.Contains(line, banner) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTitle reports whether the trimmed line, given with its upper case,
+// looks like the all-caps title
+func (t *Template) isTitle(line, upper string) bool {
+	if len(line) < t.Rules.Title.MinLength || upper != line || t.isPrayerHeader(line) {
+		return false
+	}
+	for _, exclude := range t.Rules.Title.Exclude {
+		if strings.Contains(line, exclude) {
+			return false
+		}
+	}
+	return true
+}
+
+// author cleans up an author line
+func (t *Template) author(line string) string {
+	author := strings.Trim(line, "{} ")
+	for _, prefix := range t.Rules.Author.StripPrefixes {
+		if strings.HasPrefix(author, prefix) {
+			return strings.TrimSpace(author[len(prefix):])
+		}
+	}
+	return author
+}
+
+// score rates how well the template fits a parsed post: a point for every
+// field found and for every marker the post contains
+func (t *Template) score(markers int, m *machine) int {
+	score := markers
+	for _, found := range []bool{
+		m.devo.Reading != "", m.devo.Date != "", m.devo.Version != "", m.devo.Passage != "",
+		len(m.devo.ReflectionQs) > 0, m.devo.Title != "", m.devo.Author != "", m.devo.Body != "", m.devo.Prayer != "",
+	} {
+		if found {
+			score++
+		}
+	}
+	return score
+}
+
+// markersIn counts the template's markers msg contains
+func (t *Template) markersIn(msg string) int {
+	count := 0
+	for _, marker := range t.Markers {
+		if strings.Contains(msg, marker) {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	templatesMu sync.RWMutex
+	templates   []*Template
+)
+
+func init() {
+	parsed, err := decodeTemplates(defaultTemplates)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in templates: %v", err))
+	}
+	templates = parsed
+}
+
+// decodeTemplates decodes and validates a JSON list of templates
+func decodeTemplates(data []byte) ([]*Template, error) {
+	var parsed []*Template
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode templates: %v", err)
+	}
+	for _, t := range parsed {
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return parsed, nil
+}
+
+// LoadTemplates reads templates from a JSON file and registers them. A
+// template with the same name as a registered one replaces it.
+func LoadTemplates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read templates: %v", err)
+	}
+
+	parsed, err := decodeTemplates(data)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range parsed {
+		if err := RegisterTemplate(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterTemplate adds a template to the registry, replacing a registered
+// template with the same name
+func RegisterTemplate(t *Template) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+
+	for i, existing := range templates {
+		if existing.Name == t.Name {
+			templates[i] = t
+			return nil
+		}
+	}
+	templates = append(templates, t)
+	return nil
+}
+
+// Templates returns the registered templates in detection order
+func Templates() []*Template {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	return append([]*Template(nil), templates...)
+}
+
+// lookupTemplate returns the registered template with the name, or the
+// first registered template when there is none
+func lookupTemplate(name string) *Template {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	for _, t := range templates {
+		if t.Name == name {
+			return t
+		}
+	}
+	return templates[0]
+}
+
+// detect parses msg with the registered templates and returns the best match.
+// Ties go to the template registered first. The lines are scanned once for
+// all templates, and a template is only run when finding every field could
+// beat the best score so far: a post the first template parses completely
+// is parsed once unless it carries another template's markers.
+func detect(msg string) *machine {
+	msg, truncated := truncate(msg)
+	lines, styles := normalizeLines(msg)
+	scanned := scanLines(lines)
+	msg = strings.Join(lines, "\n")
+
+	var best *machine
+	bestScore := -1
+	for _, t := range Templates() {
+		markers := t.markersIn(msg)
+		if len(fieldSections)+markers <= bestScore {
+			continue
+		}
+		m := run(lines, scanned, styles, t)
+		if score := t.score(markers, m); score > bestScore {
+			best, bestScore = m, score
+		}
+	}
+	best.truncated = truncated
+	best.derive()
+	return best
+}
@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateDetection(t *testing.T) {
+	tests := []struct {
+		file     string
+		template string
+		title    string
+		author   string
+		prayer   string
+		language string
+	}{
+		{"guest_writer.txt", "guest_writer", "WALK HUMBLY", "Pastor Mark Santos", "Lord, teach me to act justly, to love mercy and to walk humbly with You. Amen.", "en"},
+		{"holiday.txt", "holiday", "GOOD NEWS OF GREAT JOY", "Pastor Ben Villanueva", "Father, thank You for sending a Savior. Fill our homes with the joy of Christmas. Amen.", "en"},
+		{"legacy_2023.txt", "legacy_2023", "OUR EVER-PRESENT HELP", "Grace Dela Cruz", "Lord, You are my refuge. Help me not to fear. Amen.", "en"},
+		{"filipino.txt", "filipino", "MANATILI SA KANYA", "Pastor Ben Villanueva", "Panginoon, turuan Mo akong manatili sa Iyo araw-araw. Nawa'y mamunga ang aking buhay para sa Iyong kaluwalhatian. Amen.", "fil"},
+		{"taglish.txt", "filipino", "PEACE THAT GUARDS", "Grace Dela Cruz", "Lord, ibinibigay ko sa Iyo ang lahat ng aking alalahanin. Thank You for Your peace that guards my heart. Amen.", "taglish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "templates", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := Parse(string(data))
+			if result.Template != tt.template {
+				t.Errorf("Expected template %q, got %q", tt.template, result.Template)
+			}
+			devo := result.Devotional
+			if devo.Template != tt.template {
+				t.Errorf("Expected devotional template %q, got %q", tt.template, devo.Template)
+			}
+			if devo.Title != tt.title {
+				t.Errorf("Expected title %q, got %q", tt.title, devo.Title)
+			}
+			if devo.Author != tt.author {
+				t.Errorf("Expected author %q, got %q", tt.author, devo.Author)
+			}
+			if devo.Prayer != tt.prayer {
+				t.Errorf("Expected prayer %q, got %q", tt.prayer, devo.Prayer)
+			}
+			if devo.Language != tt.language {
+				t.Errorf("Expected language %q, got %q", tt.language, devo.Language)
+			}
+			if devo.Reading == "" || devo.Date == "" || devo.Passage == "" || devo.Body == "" {
+				t.Errorf("Expected reading, date, passage and body, got %+v", devo)
+			}
+		})
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.json")
+	config := `[{
+		"name": "youth",
+		"markers": ["YOUTH DEVO"],
+		"headers": {"reading": ["Text:"], "prayer": ["PRAY"]},
+		"order": ["reading", "passage", "title", "author", "body", "prayer"]
+	}]`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadTemplates(path); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	defer func() {
+		templatesMu.Lock()
+		templates = templates[:len(templates)-1]
+		templatesMu.Unlock()
+	}()
+
+	msg := "YOUTH DEVO\nText: James 1:22\n22 Do not merely listen to the word, and so deceive yourselves. Do what it says.\nDOERS NOT HEARERS\nKim Reyes\nListening is only the start.\nPRAY\nHelp me obey, Lord. Amen."
+	result := Parse(msg)
+	if result.Template != "youth" {
+		t.Fatalf("Expected the youth template, got %q", result.Template)
+	}
+	devo := result.Devotional
+	if devo.Reading != "James 1:22" || devo.Title != "DOERS NOT HEARERS" || devo.Author != "Kim Reyes" || devo.Prayer != "Help me obey, Lord. Amen." {
+		t.Errorf("Unexpected devotional: %+v", devo)
+	}
+}
+
+func TestTemplateValidation(t *testing.T) {
+	bad := []*Template{
+		{Name: ""},
+		{Name: "no-body", Order: []string{FieldTitle}},
+		{Name: "unknown-section", Order: []string{FieldTitle, "verse", FieldBody}},
+		{Name: "repeated-section", Order: []string{FieldTitle, FieldBody, FieldTitle}},
+		{Name: "no-questions-header", Order: []string{FieldReflectionQs, FieldTitle, FieldBody}},
+	}
+	for _, tmpl := range bad {
+		if err := tmpl.validate(); err == nil {
+			t.Errorf("Expected template %q to be rejected", tmpl.Name)
+		}
+	}
+}
+
+func TestTemplateOrder(t *testing.T) {
+	questionsLast := &Template{Name: "questions_last", Markers: []string{"WEEKEND DEVO"}, Order: []string{
+		FieldReading, FieldDate, FieldVersion, FieldPassage, FieldTitle, FieldAuthor, FieldBody, FieldReflectionQs, FieldPrayer,
+	}}
+	questionsLast.Headers.Banner = []string{"WEEKEND DEVO"}
+	questionsLast.Headers.Reading = []string{"Read"}
+	questionsLast.Headers.Questions = []string{"THINK ABOUT IT"}
+	questionsLast.Headers.Prayer = []string{"PRAYER"}
+
+	titleFirst := &Template{Name: "title_first", Markers: []string{"SUNDAY DEVO"}, Order: []string{
+		FieldTitle, FieldAuthor, FieldBody, FieldReading, FieldVersion, FieldPassage, FieldReflectionQs, FieldPrayer,
+	}}
+	titleFirst.Headers.Banner = []string{"SUNDAY DEVO"}
+	titleFirst.Headers.Reading = []string{"Read"}
+	titleFirst.Headers.Questions = []string{"REFLECT"}
+	titleFirst.Headers.Prayer = []string{"PRAYER"}
+	titleFirst.Rules.Title.Exclude = []string{"SUNDAY DEVO"}
+
+	for _, tmpl := range []*Template{questionsLast, titleFirst} {
+		if err := RegisterTemplate(tmpl); err != nil {
+			t.Fatalf("RegisterTemplate(%q) failed: %v", tmpl.Name, err)
+		}
+	}
+	defer func() {
+		templatesMu.Lock()
+		templates = templates[:len(templates)-2]
+		templatesMu.Unlock()
+	}()
+
+	tests := []struct {
+		template string
+		msg      string
+		title    string
+		body     string
+		question string
+		prayer   string
+	}{
+		{"questions_last", "WEEKEND DEVO\nRead Psalm 23:1-3\nAugust 16, 2025\nPsalm 23:1-3 NIV\n" +
+			"1 The LORD is my shepherd, I lack nothing.\n2 He makes me lie down in green pastures.\n3 He refreshes my soul.\n" +
+			"REST FOR THE WEARY\nGrace Dela Cruz\nHe leads us to rest (v. 2).\n" +
+			"THINK ABOUT IT\nWhere do you need rest?\nPRAYER\nLord, lead me to rest. Amen.",
+			"REST FOR THE WEARY", "He leads us to rest (v. 2).", "Where do you need rest?", "Lord, lead me to rest. Amen."},
+		{"title_first", "SUNDAY DEVO\nTHE GOOD SHEPHERD\nPastor Ben Villanueva\nHe knows each of His sheep by name (v. 3).\n" +
+			"Read John 10:1-3\nJohn 10:1-3 NIV\n1 Very truly I tell you, anyone who does not enter by the gate is a thief.\n" +
+			"3 The sheep listen to his voice.\nREFLECT\nDo you know His voice?\nPRAYER\nLord, help me hear You. Amen.",
+			"THE GOOD SHEPHERD", "He knows each of His sheep by name (v. 3).", "Do you know His voice?", "Lord, help me hear You. Amen."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			devo := ParseDevotional(tt.msg)
+			if devo.Template != tt.template {
+				t.Fatalf("Expected the %s template, got %q", tt.template, devo.Template)
+			}
+			if devo.Reading == "" || devo.Version != "NIV" || devo.Passage == "" || devo.Author == "" {
+				t.Errorf("Expected the reading, version, passage and author, got %+v", devo)
+			}
+			if devo.Title != tt.title || devo.Body != tt.body || len(devo.ReflectionQs) != 1 || devo.ReflectionQs[0] != tt.question || devo.Prayer != tt.prayer {
+				t.Errorf("Expected each section in its place, got title %q, body %q, questions %q, prayer %q",
+					devo.Title, devo.Body, devo.ReflectionQs, devo.Prayer)
+			}
+			for _, d := range DiffDevotionals(devo, ParseDevotional(Render(devo))) {
+				t.Errorf("rendered post differs in %s:\n want: %v\n  got: %v", d.Field, d.Want, d.Got)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+// Severities of validation issues. Errors are mistakes the parser can't
+// recover from, warnings are worth a second look before posting.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Validation rules
+const (
+	RuleRequiredSection = "required_section"        // every section of the template is present
+	RuleRealDate        = "real_date"               // the date exists and its weekday matches
+	RuleReadingPassage  = "reading_matches_passage" // the passage verses are the reading's verses
+	RuleTitleCaps       = "title_all_caps"          // the title is in all caps
+	RulePrayerAmen      = "prayer_ends_with_amen"   // the prayer closes with "Amen"
+)
+
+// maxMissingVerses caps the verses checked against the passage, so a reading
+// of a whole chapter range doesn't list hundreds
+const maxMissingVerses = 200
+
+// Issue is a problem found in a devotional draft
+type Issue struct {
+	Rule     string `json:"rule"`
+	Field    string `json:"field"`
+	Severity string `json:"severity"`
+	Line     int    `json:"line"` // 1-based line of the draft the issue is on, or where a missing section belongs
+	Message  string `json:"message"`
+}
+
+// Validation is the outcome of validating a devotional draft
+type Validation struct {
+	Valid      bool              `json:"valid"` // true when there are no errors
+	Template   string            `json:"template"`
+	Devotional models.Devotional `json:"devotional"`
+	Errors     []Issue           `json:"errors"`
+	Warnings   []Issue           `json:"warnings"`
+}
+
+// validator checks a parsed draft against the rules
+type validator struct {
+	m      *machine
+	issues []Issue
+}
+
+// Validate parses a draft post and checks it against the rules: the sections
+// of its template are present, the date is a real date, the passage verses
+// match the reading, the title is in all caps and the prayer ends with "Amen"
+func Validate(msg string) Validation {
+	m := detect(msg)
+	v := &validator{m: m}
+	v.checkDate()
+	v.checkTitle()
+	v.checkSections()
+	v.checkPassage()
+	v.checkPrayer()
+
+	sort.SliceStable(v.issues, func(i, j int) bool { return v.issues[i].Line < v.issues[j].Line })
+	result := Validation{
+		Template:   m.template.Name,
+		Devotional: m.devo,
+		Errors:     []Issue{},
+		Warnings:   []Issue{},
+	}
+	for _, issue := range v.issues {
+		if issue.Severity == SeverityError {
+			result.Errors = append(result.Errors, issue)
+		} else {
+			result.Warnings = append(result.Warnings, issue)
+		}
+	}
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// add records an issue on a 0-based line index
+func (v *validator) add(severity, rule, field string, index int, format string, args ...interface{}) {
+	v.issues = append(v.issues, Issue{
+		Rule:     rule,
+		Field:    field,
+		Severity: severity,
+		Line:     index + 1,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// reported reports whether an issue was already recorded for the field
+func (v *validator) reported(field string) bool {
+	for _, issue := range v.issues {
+		if issue.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSections reports the sections of the template that are missing, at
+// the line where they belong
+func (v *validator) checkSections() {
+	m := v.m
+	t := m.template
+	sections := map[string]lineRange{
+		FieldReading: m.reading, FieldDate: m.date, FieldVersion: m.version, FieldPassage: m.passage,
+		FieldReflectionQs: m.questionsHeader, FieldTitle: m.title, FieldAuthor: m.author, FieldBody: m.body, FieldPrayer: m.prayerHeader,
+	}
+	// A mixed-case title is taken as a question, so the author and body are
+	// missing because of it
+	mixedCaseTitle := v.reported(FieldTitle)
+
+	next := 0
+	for _, field := range t.Order {
+		r := sections[field]
+		if r.found() {
+			next = r.end
+			switch {
+			case field == FieldReflectionQs && !m.questions.found():
+				v.add(SeverityError, RuleRequiredSection, field, r.start, "the %s header has no questions", t.Headers.Questions[0])
+			case field == FieldReflectionQs:
+				next = m.questions.end
+			case field == FieldPrayer && !m.prayer.found():
+				v.add(SeverityError, RuleRequiredSection, field, r.start, "the %s header has no prayer", t.Headers.Prayer[0])
+			case field == FieldPrayer:
+				next = m.prayer.end
+			}
+			continue
+		}
+		if v.reported(field) || (mixedCaseTitle && (field == FieldAuthor || field == FieldBody)) {
+			continue
+		}
+
+		at := next
+		for at < len(m.lines)-1 && strings.TrimSpace(m.lines[at]) == "" {
+			at++
+		}
+		if field == FieldReflectionQs {
+			// Without the header the questions are taken as part of the passage
+			for i := m.passage.start; m.passage.found() && i < m.passage.end; i++ {
+				if strings.HasSuffix(strings.TrimSpace(m.lines[i]), "?") {
+					at = i
+					break
+				}
+			}
+		}
+		v.add(SeverityError, RuleRequiredSection, field, at, "missing %s", v.describe(field))
+	}
+}
+
+// describe names a missing section for its message
+func (v *validator) describe(field string) string {
+	t := v.m.template
+	switch field {
+	case FieldReading:
+		return fmt.Sprintf("the %q line with the reading", headerOr(t.Headers.Reading, "Read"))
+	case FieldDate:
+		return "the date"
+	case FieldVersion:
+		if reading := v.m.devo.Reading; reading != "" {
+			return fmt.Sprintf("the version line, e.g. %q", reading+" NIV")
+		}
+		return "the version line"
+	case FieldPassage:
+		return "the passage verses"
+	case FieldReflectionQs:
+		return "the " + t.Headers.Questions[0] + " header"
+	case FieldTitle:
+		return "the all-caps title"
+	case FieldAuthor:
+		return "the author line after the title"
+	case FieldBody:
+		return "the body"
+	case FieldPrayer:
+		return "the " + t.Headers.Prayer[0] + " header"
+	}
+	return field
+}
+
+// checkDate reports a date that doesn't exist, such as February 30, and a
+// weekday that doesn't match the date
+func (v *validator) checkDate() {
+	m := v.m
+	if m.devo.Date != "" {
+		if w := m.dateValue.weekdayWarning(); w != "" {
+			v.add(SeverityWarning, RuleRealDate, FieldDate, m.date.start, "%s", w)
+		}
+		return
+	}
+
+	end := len(m.lines)
+	if m.title.found() {
+		end = m.title.start
+	}
+	for i := 0; i < end; i++ {
+		if text := invalidDateOn(m.lines[i]); text != "" {
+			v.add(SeverityError, RuleRealDate, FieldDate, i, "%q is not a real date", text)
+			return
+		}
+	}
+}
+
+// invalidDateOn returns the text of a line written like a date that is not a
+// real one, or ""
+func invalidDateOn(line string) string {
+	if !strings.ContainsAny(line, "0123456789") {
+		return ""
+	}
+	for _, m := range bracedPattern.FindAllStringSubmatch(line, -1) {
+		if invalidDate(m[1]) {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	if len(line) <= maxDateLength && invalidDate(line) {
+		return strings.TrimSpace(line)
+	}
+	return ""
+}
+
+// checkTitle reports a title that is not in all caps. The parser can't tell
+// it apart from a question, so it is the first question that doesn't end
+// with a question mark.
+func (v *validator) checkTitle() {
+	m := v.m
+	if m.title.found() || !m.questions.found() {
+		return
+	}
+	for i := m.questions.start; i < m.questions.end; i++ {
+		line := strings.Trim(m.lines[i], "{} ")
+		if line == "" || strings.HasSuffix(line, "?") {
+			continue
+		}
+		if len(line) <= 80 && !strings.HasSuffix(line, ".") {
+			v.add(SeverityError, RuleTitleCaps, FieldTitle, i, "title %q is not in all caps", line)
+		}
+		return
+	}
+}
+
+// checkPassage reports passage verses outside the reading, and verses of the
+// reading missing from the passage
+func (v *validator) checkPassage() {
+	m := v.m
+	devo := m.devo
+	if devo.Reading == "" || len(devo.Verses) == 0 {
+		return
+	}
+	refs, err := scripture.Parse(devo.Reading)
+	if err != nil || len(refs) != 1 {
+		return
+	}
+	ref := refs[0]
+
+	// The verses are parsed from the lines starting with a verse number
+	var verseLines []int
+	for i := m.passage.start; i < m.passage.end; i++ {
+		if verseLinePattern.MatchString(strings.Trim(m.lines[i], "{} ")) {
+			verseLines = append(verseLines, i)
+		}
+	}
+	present := make(map[[2]int]bool)
+	for i, verse := range devo.Verses {
+		present[[2]int{verse.Chapter, verse.Number}] = true
+		if ref.Contains(verse.Chapter, verse.Number) {
+			continue
+		}
+		line := m.passage.start
+		if len(verseLines) == len(devo.Verses) {
+			line = verseLines[i]
+		}
+		v.add(SeverityError, RuleReadingPassage, FieldPassage, line, "verse %d:%d is outside the reading %s", verse.Chapter, verse.Number, devo.Reading)
+	}
+
+	var missing []string
+	for _, r := range ref.Ranges {
+		if r.StartChapter != r.EndChapter || r.StartVerse == 0 || r.EndVerse-r.StartVerse >= maxMissingVerses {
+			continue
+		}
+		for n := r.StartVerse; n <= r.EndVerse; n++ {
+			if !present[[2]int{r.StartChapter, n}] {
+				missing = append(missing, fmt.Sprintf("%d:%d", r.StartChapter, n))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		verses := "verse"
+		if len(missing) > 1 {
+			verses = "verses"
+		}
+		v.add(SeverityWarning, RuleReadingPassage, FieldPassage, m.passage.start, "the passage is missing %s %s of the reading %s", verses, strings.Join(missing, ", "), devo.Reading)
+	}
+}
+
+// checkPrayer reports a prayer that doesn't end with "Amen"
+func (v *validator) checkPrayer() {
+	m := v.m
+	if m.devo.Prayer == "" {
+		return
+	}
+	closing := strings.ToLower(strings.TrimRight(m.devo.Prayer, " .!\n"))
+	if !strings.HasSuffix(closing, "amen") {
+		v.add(SeverityWarning, RulePrayerAmen, FieldPrayer, m.prayer.end-1, "the prayer does not end with \"Amen\"")
+	}
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// validDraft is a complete post in the standard layout
+const validDraft = `DAILY DEVOTIONAL
+
+Read Matthew 6:16-18
+Saturday, August 2, 2025
+
+Matthew 6:16-18 NIV
+16 When you fast, do not look somber as the hypocrites do.
+17 But when you fast, put oil on your head and wash your face,
+18 so that it will not be obvious to others that you are fasting.
+
+REFLECTION QUESTIONS
+What spiritual habit do you do partly for others to notice?
+
+FASTING IN SECRET
+Pastor Ben Villanueva
+
+Jesus assumes that His followers will fast.
+
+PRAYER
+Father, help me to seek Your reward alone. Amen.`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		draft    string
+		errors   []string // "line rule: message" of the expected errors
+		warnings []string
+	}{
+		{
+			name:  "valid",
+			draft: validDraft,
+		},
+		{
+			name:   "missing questions header",
+			draft:  strings.Replace(validDraft, "REFLECTION QUESTIONS\n", "", 1),
+			errors: []string{"11 required_section: missing the REFLECTION QUESTIONS header"},
+		},
+		{
+			name:   "questions header without questions",
+			draft:  strings.Replace(validDraft, "What spiritual habit do you do partly for others to notice?\n", "", 1),
+			errors: []string{"11 required_section: the REFLECTION QUESTIONS header has no questions"},
+		},
+		{
+			name:   "not a real date",
+			draft:  strings.Replace(validDraft, "Saturday, August 2, 2025", "February 30, 2025", 1),
+			errors: []string{`4 real_date: "February 30, 2025" is not a real date`},
+		},
+		{
+			name:     "weekday mismatch",
+			draft:    strings.Replace(validDraft, "Saturday", "Sunday", 1),
+			warnings: []string{"4 real_date: weekday Sunday does not match August 2, 2025, a Saturday"},
+		},
+		{
+			name:   "verse outside the reading",
+			draft:  strings.Replace(validDraft, "18 so that", "19 so that", 1),
+			errors: []string{"9 reading_matches_passage: verse 6:19 is outside the reading Matthew 6:16-18"},
+			warnings: []string{
+				"7 reading_matches_passage: the passage is missing verse 6:18 of the reading Matthew 6:16-18",
+			},
+		},
+		{
+			name:   "mixed-case title",
+			draft:  strings.Replace(validDraft, "FASTING IN SECRET", "Fasting in Secret", 1),
+			errors: []string{`14 title_all_caps: title "Fasting in Secret" is not in all caps`},
+		},
+		{
+			name:     "prayer without amen",
+			draft:    strings.Replace(validDraft, " Amen.", "", 1),
+			warnings: []string{`20 prayer_ends_with_amen: the prayer does not end with "Amen"`},
+		},
+		{
+			name:   "missing prayer",
+			draft:  validDraft[:strings.Index(validDraft, "\nPRAYER")],
+			errors: []string{"18 required_section: missing the PRAYER header"},
+		},
+	}
+
+	format := func(issues []Issue) []string {
+		var lines []string
+		for _, issue := range issues {
+			lines = append(lines, strings.Join([]string{strconv.Itoa(issue.Line), " ", issue.Rule, ": ", issue.Message}, ""))
+		}
+		return lines
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(tt.draft)
+			if got := format(result.Errors); strings.Join(got, "\n") != strings.Join(tt.errors, "\n") {
+				t.Errorf("errors:\n got: %q\nwant: %q", got, tt.errors)
+			}
+			if got := format(result.Warnings); strings.Join(got, "\n") != strings.Join(tt.warnings, "\n") {
+				t.Errorf("warnings:\n got: %q\nwant: %q", got, tt.warnings)
+			}
+			if result.Valid != (len(tt.errors) == 0) {
+				t.Errorf("valid = %v with errors %v", result.Valid, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"lwnra-devo-api/models"
+	"lwnra-devo-api/scripture"
+)
+
+// verseLinePattern matches a line starting a verse, e.g. "16 When you fast..."
+var verseLinePattern = regexp.MustCompile(`^(\d+)\s+(.*)$`)
+
+// parseVerses splits a passage into verses. Lines that don't start with a
+// verse number continue the previous verse. The chapter starts at the
+// reading's first chapter and advances whenever the verse numbers restart.
+func parseVerses(passage, reading string) []models.Verse {
+	if passage == "" {
+		return nil
+	}
+
+	chapter := 0
+	if refs, err := scripture.Parse(reading); err == nil {
+		chapter = refs[0].Ranges[0].StartChapter
+	}
+
+	var verses []models.Verse
+	for _, line := range strings.Split(passage, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if m := verseLinePattern.FindStringSubmatch(line); m != nil {
+			number, _ := strconv.Atoi(m[1])
+			if n := len(verses); n > 0 && number <= verses[n-1].Number && chapter > 0 {
+				chapter++
+			}
+			verses = append(verses, models.Verse{Chapter: chapter, Number: number, Text: strings.TrimSpace(m[2])})
+			continue
+		}
+
+		// A wrapped line of the current verse
+		if n := len(verses); n > 0 {
+			verses[n-1].Text += " " + line
+		}
+	}
+	return verses
+}
+
+// versesOutsideReading returns a warning for every verse that falls outside
+// the reading's range
+func versesOutsideReading(verses []models.Verse, reading string) []string {
+	refs, err := scripture.Parse(reading)
+	if err != nil || len(refs) != 1 {
+		return nil
+	}
+
+	var warnings []string
+	for _, v := range verses {
+		if !refs[0].Contains(v.Chapter, v.Number) {
+			warnings = append(warnings, fmt.Sprintf("verse %d:%d is outside the reading %s", v.Chapter, v.Number, reading))
+		}
+	}
+	return warnings
+}
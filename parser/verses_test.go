@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersesOutsideReading(t *testing.T) {
+	msg := "DAILY DEVOTIONAL\nRead Matthew 6:16-18\nAugust 2, 2025\nMatthew 6:16-18 NIV\n" +
+		"16 When you fast,\ndo not look somber.\n17 But when you fast, put oil on your head\n19 Do not store up for yourselves treasures on earth\n" +
+		"REFLECTION QUESTIONS\nWhat do you do for others to notice?"
+
+	result := Parse(msg)
+	verses := result.Devotional.Verses
+	if len(verses) != 3 {
+		t.Fatalf("Expected 3 verses, got %+v", verses)
+	}
+	if verses[0].Text != "When you fast, do not look somber." {
+		t.Errorf("Expected wrapped verse to be joined, got %q", verses[0].Text)
+	}
+
+	passage := result.Fields[FieldPassage]
+	if len(passage.Warnings) != 1 || !strings.Contains(passage.Warnings[0], "verse 6:19") {
+		t.Errorf("Expected a warning for verse 6:19, got %v", passage.Warnings)
+	}
+	if passage.Confidence != 0.7 {
+		t.Errorf("Expected confidence 0.7, got %v", passage.Confidence)
+	}
+}
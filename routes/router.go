@@ -11,13 +11,17 @@ import (
 type Router struct {
 	devotionalHandler *handlers.DevotionalHandler
 	systemHandler     *handlers.SystemHandler
+	statsHandler      *handlers.StatsHandler
+	adminHandler      *handlers.AdminHandler
 }
 
 // NewRouter creates a new router with handlers
-func NewRouter(devotionalHandler *handlers.DevotionalHandler, systemHandler *handlers.SystemHandler) *Router {
+func NewRouter(devotionalHandler *handlers.DevotionalHandler, systemHandler *handlers.SystemHandler, statsHandler *handlers.StatsHandler, adminHandler *handlers.AdminHandler) *Router {
 	return &Router{
 		devotionalHandler: devotionalHandler,
 		systemHandler:     systemHandler,
+		statsHandler:      statsHandler,
+		adminHandler:      adminHandler,
 	}
 }
 
@@ -25,7 +29,7 @@ func NewRouter(devotionalHandler *handlers.DevotionalHandler, systemHandler *han
 func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Add CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 	// Handle preflight requests
@@ -36,16 +40,51 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Route requests based on path and method
 	path := strings.TrimSuffix(r.URL.Path, "/")
-	
+
+	// Admin endpoints require the admin token
+	if strings.HasPrefix(path, "/api/admin/") && !router.adminHandler.Authorize(w, r) {
+		return
+	}
+
 	switch {
 	case path == "/api/devotionals" && r.Method == http.MethodGet:
 		router.devotionalHandler.GetDevotionals(w, r)
+	case path == "/api/devotionals/today/verse" && r.Method == http.MethodGet:
+		router.devotionalHandler.GetTodayVerse(w, r)
+	case strings.HasPrefix(path, "/api/devotionals/") && strings.HasSuffix(path, "/image") && r.Method == http.MethodGet:
+		router.devotionalHandler.GetDevotionalImage(w, r)
 	case strings.HasPrefix(path, "/api/devotionals/") && r.Method == http.MethodGet:
 		router.devotionalHandler.GetDevotionalByDate(w, r)
 	case path == "/api/devotionals/sync" && r.Method == http.MethodPost:
 		router.devotionalHandler.SyncDevotionals(w, r)
 	case path == "/api/devotionals/parse" && r.Method == http.MethodPost:
 		router.devotionalHandler.ParseDevotional(w, r)
+	case path == "/api/devotionals/validate" && r.Method == http.MethodPost:
+		router.devotionalHandler.ValidateDevotional(w, r)
+	case strings.HasPrefix(path, "/api/admin/posts/") && strings.HasSuffix(path, "/sync") && r.Method == http.MethodPost:
+		router.adminHandler.SyncPost(w, r)
+	case strings.HasPrefix(path, "/api/admin/devotionals/") && r.Method == http.MethodGet:
+		router.adminHandler.GetDevotionalOverrides(w, r)
+	case strings.HasPrefix(path, "/api/admin/devotionals/") && r.Method == http.MethodPatch:
+		router.adminHandler.PatchDevotional(w, r)
+	case path == "/api/admin/review" && r.Method == http.MethodGet:
+		router.adminHandler.GetReviewQueue(w, r)
+	case strings.HasPrefix(path, "/api/admin/review/") && strings.HasSuffix(path, "/approve") && r.Method == http.MethodPost:
+		router.adminHandler.ApproveReview(w, r)
+	case strings.HasPrefix(path, "/api/admin/review/") && r.Method == http.MethodPatch:
+		router.adminHandler.FixReview(w, r)
+	case path == "/api/versions" && r.Method == http.MethodGet:
+		router.devotionalHandler.GetVersions(w, r)
+	case path == "/api/series" && r.Method == http.MethodGet:
+		router.devotionalHandler.GetSeries(w, r)
+	case strings.HasPrefix(path, "/api/series/") && r.Method == http.MethodGet:
+		router.devotionalHandler.GetSeriesByID(w, r)
+	case path == "/api/cross-references" && r.Method == http.MethodGet:
+		router.devotionalHandler.GetCrossReferences(w, r)
+	case path == "/api/stats/engagement" && r.Method == http.MethodGet:
+		router.statsHandler.GetTopEngagement(w, r)
+	case strings.HasPrefix(path, "/api/stats/engagement/") && r.Method == http.MethodGet:
+		router.statsHandler.GetEngagementHistory(w, r)
 	case path == "/api/scheduler/status" && r.Method == http.MethodGet:
 		router.systemHandler.GetSchedulerStatus(w, r)
 	case path == "/health" && r.Method == http.MethodGet:
@@ -66,16 +105,32 @@ func (router *Router) apiInfo(w http.ResponseWriter, r *http.Request) {
 		"version": "1.0.0",
 		"description": "REST API for managing daily devotionals with automated scheduling",
 		"endpoints": {
-			"GET /api/devotionals": "Get all devotionals (with optional ?limit=N)",
-			"GET /api/devotionals/{date}": "Get devotional by date (YYYY-MM-DD format)",
+			"GET /api/devotionals": "Get all devotionals (with optional ?limit=N&lang=en|fil|taglish&q=text&format=html|markdown)",
+			"GET /api/devotionals/{date}": "Get devotional by date (YYYY-MM-DD format, with optional ?format=html|markdown)",
+			"GET /api/devotionals/{date}/image": "Get the post image of a devotional",
+			"GET /api/devotionals/today/verse": "Memory verse and pull quote of today's devotional",
 			"POST /api/devotionals/sync": "Sync devotionals from Facebook",
 			"POST /api/devotionals/parse": "Parse devotional text",
+			"POST /api/devotionals/validate": "Check a devotional draft for missing sections and mistakes before posting",
+			"GET /api/versions": "Bible versions with their full names, language and copyright notice",
+			"GET /api/series": "Devotional series, named in the posts or reading through one book",
+			"GET /api/series/{id}": "A series with its devotionals in order",
+			"GET /api/cross-references": "Passages cited in devotional bodies outside their readings (with optional ?book=Name)",
+			"GET /api/stats/engagement": "Top devotionals by engagement (with optional ?period=day|week|month|year|all&limit=N)",
+			"GET /api/stats/engagement/{date}": "Engagement history of a devotional",
+			"POST /api/admin/posts/{fbPostID}/sync": "Re-fetch and re-parse a single Facebook post (admin)",
+			"GET /api/admin/devotionals/{id}": "Get a devotional with its field overrides next to the parsed values (admin)",
+			"PATCH /api/admin/devotionals/{id}": "Override fields of a devotional, kept when its post is parsed again (admin)",
+			"GET /api/admin/review": "List devotionals held for review because their parse is incomplete (admin)",
+			"PATCH /api/admin/review/{id}": "Correct fields of a devotional held for review (admin)",
+			"POST /api/admin/review/{id}/approve": "Publish a corrected devotional held for review (admin)",
 			"GET /api/scheduler/status": "Get scheduler status and next run time",
 			"GET /health": "Health check"
 		},
 		"scheduler": {
 			"sync_time": "4:45 AM Philippine Time (UTC+8)",
 			"backup_sync": "5:15 AM Philippine Time (UTC+8)",
+			"engagement_refresh": "Every 6 hours for posts from the last 30 days",
 			"timezone": "Asia/Manila"
 		}
 	}`
@@ -7,18 +7,24 @@ import (
 	"github.com/robfig/cron/v3"
 	"lwnra-devo-api/database"
 	"lwnra-devo-api/facebook"
-	"lwnra-devo-api/parser"
+	"lwnra-devo-api/importer"
+	"lwnra-devo-api/storage"
 )
 
+// engagementWindow is how far back posts keep getting their engagement refreshed
+const engagementWindow = 30 * 24 * time.Hour
+
 // Scheduler handles automated tasks
 type Scheduler struct {
-	cron *cron.Cron
-	db   *database.DB
-	fb   *facebook.Client
+	cron        *cron.Cron
+	db          *database.DB
+	fb          *facebook.Client
+	importer    *importer.Importer
+	syncEntries []cron.EntryID
 }
 
 // New creates a new scheduler instance
-func New(db *database.DB, fb *facebook.Client) *Scheduler {
+func New(db *database.DB, fb *facebook.Client, images *storage.ImageStore) *Scheduler {
 	// Use Philippine timezone (UTC+8)
 	philippineLocation, err := time.LoadLocation("Asia/Manila")
 	if err != nil {
@@ -29,9 +35,10 @@ func New(db *database.DB, fb *facebook.Client) *Scheduler {
 	c := cron.New(cron.WithLocation(philippineLocation))
 
 	return &Scheduler{
-		cron: c,
-		db:   db,
-		fb:   fb,
+		cron:     c,
+		db:       db,
+		fb:       fb,
+		importer: importer.New(db, fb, images),
 	}
 }
 
@@ -39,16 +46,25 @@ func New(db *database.DB, fb *facebook.Client) *Scheduler {
 func (s *Scheduler) Start() {
 	// Schedule devotional sync at 4:45 AM Philippine time every day
 	// Cron format: "45 4 * * *" = 45 minutes, 4 hours, every day of month, every month, every day of week
-	_, err := s.cron.AddFunc("45 4 * * *", s.syncDevotionals)
+	id, err := s.cron.AddFunc("45 4 * * *", s.syncDevotionals)
 	if err != nil {
 		log.Printf("Failed to schedule devotional sync: %v", err)
 		return
 	}
+	s.syncEntries = append(s.syncEntries, id)
 
 	// Optional: Also run a backup sync at 5:15 AM in case the first one fails
-	_, err = s.cron.AddFunc("15 5 * * *", s.syncDevotionals)
+	id, err = s.cron.AddFunc("15 5 * * *", s.syncDevotionals)
 	if err != nil {
 		log.Printf("Failed to schedule backup devotional sync: %v", err)
+	} else {
+		s.syncEntries = append(s.syncEntries, id)
+	}
+
+	// Refresh reaction, comment and share counts every 6 hours
+	_, err = s.cron.AddFunc("0 */6 * * *", s.refreshEngagement)
+	if err != nil {
+		log.Printf("Failed to schedule engagement refresh: %v", err)
 	}
 
 	s.cron.Start()
@@ -77,11 +93,8 @@ func (s *Scheduler) syncDevotionals() {
 	
 	syncCount := 0
 	for _, post := range devotionalPosts {
-		// Parse the devotional content
-		devotional := parser.ParseDevotional(post.Message)
-
-		// Save to database
-		err = s.db.SaveDevotional(devotional)
+		// Parse the devotional content, save it and store its images
+		_, err = s.importer.ImportPost(post)
 		if err != nil {
 			log.Printf("Failed to save devotional during scheduled sync: %v", err)
 			continue
@@ -97,13 +110,43 @@ func (s *Scheduler) syncDevotionals() {
 	}
 }
 
-// GetNextRun returns the next scheduled run time
+// refreshEngagement stores a new engagement snapshot for every recent post
+func (s *Scheduler) refreshEngagement() {
+	refs, err := s.db.GetPostsSince(time.Now().Add(-engagementWindow))
+	if err != nil {
+		log.Printf("Failed to load posts for engagement refresh: %v", err)
+		return
+	}
+
+	now := time.Now()
+	refreshed := 0
+	for _, ref := range refs {
+		engagement, err := s.fb.GetPostEngagement(ref.PostID)
+		if err != nil {
+			log.Printf("Failed to fetch engagement for post %s: %v", ref.PostID, err)
+			continue
+		}
+
+		if err := s.db.SaveEngagementSnapshot(ref.DevotionalID, engagement, now); err != nil {
+			log.Printf("Failed to save engagement for post %s: %v", ref.PostID, err)
+			continue
+		}
+		refreshed++
+	}
+
+	log.Printf("Engagement refresh completed - %d of %d posts updated", refreshed, len(refs))
+}
+
+// GetNextRun returns the next scheduled devotional sync time
 func (s *Scheduler) GetNextRun() time.Time {
-	entries := s.cron.Entries()
-	if len(entries) > 0 {
-		return entries[0].Next
+	var next time.Time
+	for _, id := range s.syncEntries {
+		entry := s.cron.Entry(id)
+		if entry.Valid() && (next.IsZero() || entry.Next.Before(next)) {
+			next = entry.Next
+		}
 	}
-	return time.Time{}
+	return next
 }
 
 // IsRunning returns whether the scheduler is currently running
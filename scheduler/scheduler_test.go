@@ -6,6 +6,7 @@ import (
 
 	"lwnra-devo-api/database"
 	"lwnra-devo-api/facebook"
+	"lwnra-devo-api/storage"
 )
 
 func TestSchedulerCreation(t *testing.T) {
@@ -20,7 +21,7 @@ func TestSchedulerCreation(t *testing.T) {
 	fbClient := facebook.New("test_token")
 
 	// Create scheduler
-	sched := New(db, fbClient)
+	sched := New(db, fbClient, storage.NewImageStore(t.TempDir()))
 
 	if sched == nil {
 		t.Fatal("Scheduler creation failed")
@@ -46,7 +47,7 @@ func TestSchedulerTimezone(t *testing.T) {
 	db, _ := database.New(":memory:")
 	defer db.Close()
 	fbClient := facebook.New("test_token")
-	sched := New(db, fbClient)
+	sched := New(db, fbClient, storage.NewImageStore(t.TempDir()))
 
 	// Start scheduler
 	sched.Start()
@@ -75,7 +76,7 @@ func TestSchedulerStartStop(t *testing.T) {
 	db, _ := database.New(":memory:")
 	defer db.Close()
 	fbClient := facebook.New("test_token")
-	sched := New(db, fbClient)
+	sched := New(db, fbClient, storage.NewImageStore(t.TempDir()))
 
 	// Start scheduler
 	sched.Start()
@@ -0,0 +1,194 @@
+// Package scripture parses and formats Bible references such as
+// "Matthew 6:16-18", "1 Cor 13", "Jn 3:16; 4:1" or "Mga Awit 23".
+package scripture
+
+import (
+	"strings"
+)
+
+// Testament is the part of the Bible a book belongs to
+type Testament string
+
+const (
+	OldTestament Testament = "OT"
+	NewTestament Testament = "NT"
+)
+
+// Book is one of the 66 books of the Protestant canon
+type Book struct {
+	Name      string    // canonical English name, e.g. "1 Corinthians"
+	Abbrev    string    // canonical abbreviation, e.g. "1 Cor"
+	Filipino  string    // Filipino name, e.g. "1 Mga Taga-Corinto"
+	Testament Testament // OT or NT
+	Chapters  int       // number of chapters
+
+	aliases []string // other abbreviations and names
+}
+
+// books is the canonical book table, in canonical order
+var books = []Book{
+	{"Genesis", "Gen", "Genesis", OldTestament, 50, []string{"ge", "gn"}},
+	{"Exodus", "Exod", "Exodo", OldTestament, 40, []string{"ex", "exo"}},
+	{"Leviticus", "Lev", "Levitico", OldTestament, 27, []string{"le", "lv"}},
+	{"Numbers", "Num", "Mga Bilang", OldTestament, 36, []string{"nu", "nm", "nb"}},
+	{"Deuteronomy", "Deut", "Deuteronomio", OldTestament, 34, []string{"dt", "de"}},
+	{"Joshua", "Josh", "Josue", OldTestament, 24, []string{"jos", "jsh"}},
+	{"Judges", "Judg", "Mga Hukom", OldTestament, 21, []string{"jdg", "jg", "jdgs"}},
+	{"Ruth", "Ruth", "Ruth", OldTestament, 4, []string{"rth", "ru"}},
+	{"1 Samuel", "1 Sam", "1 Samuel", OldTestament, 31, []string{"1 sa", "1 sm"}},
+	{"2 Samuel", "2 Sam", "2 Samuel", OldTestament, 24, []string{"2 sa", "2 sm"}},
+	{"1 Kings", "1 Kgs", "1 Mga Hari", OldTestament, 22, []string{"1 ki", "1 kin"}},
+	{"2 Kings", "2 Kgs", "2 Mga Hari", OldTestament, 25, []string{"2 ki", "2 kin"}},
+	{"1 Chronicles", "1 Chr", "1 Mga Cronica", OldTestament, 29, []string{"1 ch", "1 chron"}},
+	{"2 Chronicles", "2 Chr", "2 Mga Cronica", OldTestament, 36, []string{"2 ch", "2 chron"}},
+	{"Ezra", "Ezra", "Ezra", OldTestament, 10, []string{"ezr"}},
+	{"Nehemiah", "Neh", "Nehemias", OldTestament, 13, []string{"ne"}},
+	{"Esther", "Esth", "Ester", OldTestament, 10, []string{"est", "es"}},
+	{"Job", "Job", "Job", OldTestament, 42, []string{"jb"}},
+	{"Psalms", "Ps", "Mga Awit", OldTestament, 150, []string{"psalm", "psa", "pss", "psm", "awit"}},
+	{"Proverbs", "Prov", "Mga Kawikaan", OldTestament, 31, []string{"pr", "prv", "pro", "kawikaan"}},
+	{"Ecclesiastes", "Eccl", "Mangangaral", OldTestament, 12, []string{"ec", "ecc", "qoh", "eclesiastes"}},
+	{"Song of Solomon", "Song", "Awit ng mga Awit", OldTestament, 8, []string{"song of songs", "sos", "canticles", "awit ni solomon"}},
+	{"Isaiah", "Isa", "Isaias", OldTestament, 66, []string{"is"}},
+	{"Jeremiah", "Jer", "Jeremias", OldTestament, 52, []string{"je", "jr"}},
+	{"Lamentations", "Lam", "Mga Panaghoy", OldTestament, 5, []string{"la", "panaghoy"}},
+	{"Ezekiel", "Ezek", "Ezekiel", OldTestament, 48, []string{"eze", "ezk"}},
+	{"Daniel", "Dan", "Daniel", OldTestament, 12, []string{"da", "dn"}},
+	{"Hosea", "Hos", "Oseas", OldTestament, 14, []string{"ho"}},
+	{"Joel", "Joel", "Joel", OldTestament, 3, []string{"jl"}},
+	{"Amos", "Amos", "Amos", OldTestament, 9, []string{"am"}},
+	{"Obadiah", "Obad", "Obadias", OldTestament, 1, []string{"ob"}},
+	{"Jonah", "Jonah", "Jonas", OldTestament, 4, []string{"jnh", "jon"}},
+	{"Micah", "Mic", "Mikas", OldTestament, 7, []string{"mc"}},
+	{"Nahum", "Nah", "Nahum", OldTestament, 3, []string{"na"}},
+	{"Habakkuk", "Hab", "Habakuk", OldTestament, 3, []string{"hb"}},
+	{"Zephaniah", "Zeph", "Zefanias", OldTestament, 3, []string{"zep", "zp"}},
+	{"Haggai", "Hag", "Hagai", OldTestament, 2, []string{"hg"}},
+	{"Zechariah", "Zech", "Zacarias", OldTestament, 14, []string{"zec", "zc"}},
+	{"Malachi", "Mal", "Malakias", OldTestament, 4, []string{"ml"}},
+	{"Matthew", "Matt", "Mateo", NewTestament, 28, []string{"mt", "mat"}},
+	{"Mark", "Mark", "Marcos", NewTestament, 16, []string{"mk", "mrk", "mr"}},
+	{"Luke", "Luke", "Lucas", NewTestament, 24, []string{"lk", "luk"}},
+	{"John", "John", "Juan", NewTestament, 21, []string{"jn", "jhn", "joh"}},
+	{"Acts", "Acts", "Mga Gawa", NewTestament, 28, []string{"ac", "act", "gawa"}},
+	{"Romans", "Rom", "Mga Taga-Roma", NewTestament, 16, []string{"ro", "rm"}},
+	{"1 Corinthians", "1 Cor", "1 Mga Taga-Corinto", NewTestament, 16, []string{"1 co"}},
+	{"2 Corinthians", "2 Cor", "2 Mga Taga-Corinto", NewTestament, 13, []string{"2 co"}},
+	{"Galatians", "Gal", "Mga Taga-Galacia", NewTestament, 6, []string{"ga"}},
+	{"Ephesians", "Eph", "Mga Taga-Efeso", NewTestament, 6, []string{"ephes"}},
+	{"Philippians", "Phil", "Mga Taga-Filipos", NewTestament, 4, []string{"php", "pp"}},
+	{"Colossians", "Col", "Mga Taga-Colosas", NewTestament, 4, []string{"cl"}},
+	{"1 Thessalonians", "1 Thess", "1 Mga Taga-Tesalonica", NewTestament, 5, []string{"1 th", "1 thes"}},
+	{"2 Thessalonians", "2 Thess", "2 Mga Taga-Tesalonica", NewTestament, 3, []string{"2 th", "2 thes"}},
+	{"1 Timothy", "1 Tim", "1 Timoteo", NewTestament, 6, []string{"1 ti"}},
+	{"2 Timothy", "2 Tim", "2 Timoteo", NewTestament, 4, []string{"2 ti"}},
+	{"Titus", "Titus", "Tito", NewTestament, 3, []string{"tit", "ti"}},
+	{"Philemon", "Phlm", "Filemon", NewTestament, 1, []string{"philem", "phm", "pm"}},
+	{"Hebrews", "Heb", "Mga Hebreo", NewTestament, 13, []string{"he"}},
+	{"James", "Jas", "Santiago", NewTestament, 5, []string{"jm", "jam"}},
+	{"1 Peter", "1 Pet", "1 Pedro", NewTestament, 5, []string{"1 pe", "1 pt"}},
+	{"2 Peter", "2 Pet", "2 Pedro", NewTestament, 3, []string{"2 pe", "2 pt"}},
+	{"1 John", "1 John", "1 Juan", NewTestament, 5, []string{"1 jn", "1 jhn", "1 jo"}},
+	{"2 John", "2 John", "2 Juan", NewTestament, 1, []string{"2 jn", "2 jhn", "2 jo"}},
+	{"3 John", "3 John", "3 Juan", NewTestament, 1, []string{"3 jn", "3 jhn", "3 jo"}},
+	{"Jude", "Jude", "Judas", NewTestament, 1, []string{"jud", "jd"}},
+	{"Revelation", "Rev", "Pahayag", NewTestament, 22, []string{"re", "rv", "revelations", "apocalypse"}},
+}
+
+// bookIndex maps every normalized name, abbreviation and alias to its book
+var bookIndex = make(map[string]*Book)
+
+func init() {
+	for i := range books {
+		b := &books[i]
+		names := append([]string{b.Name, b.Abbrev, b.Filipino}, b.aliases...)
+		// Filipino names are also written without "Mga" or "Mga Taga-",
+		// e.g. "Awit 23", "Roma 8:28", "1 Corinto 13"
+		for _, prefix := range []string{"Mga Taga-", "Mga "} {
+			if n, ok := cutWord(b.Filipino, prefix); ok {
+				names = append(names, n)
+				break
+			}
+		}
+		for _, name := range names {
+			key := normalizeBook(name)
+			if _, taken := bookIndex[key]; !taken {
+				bookIndex[key] = b
+			}
+		}
+	}
+}
+
+// cutWord removes prefix from a book name, keeping a leading book number
+func cutWord(name, prefix string) (string, bool) {
+	number := ""
+	if len(name) > 2 && name[0] >= '1' && name[0] <= '3' && name[1] == ' ' {
+		number, name = name[:2], name[2:]
+	}
+	if !strings.HasPrefix(name, prefix) {
+		return "", false
+	}
+	return number + strings.TrimPrefix(name, prefix), true
+}
+
+// Books returns the 66 books in canonical order
+func Books() []Book {
+	return append([]Book(nil), books...)
+}
+
+// LookupBook finds a book by name, abbreviation or Filipino name, ignoring
+// case, periods and spacing. Ordinals may be written as "1", "I" or "First".
+// An unambiguous prefix of at least three letters of a book name also matches.
+func LookupBook(name string) (*Book, bool) {
+	key := normalizeBook(name)
+	if key == "" {
+		return nil, false
+	}
+	if b, ok := bookIndex[key]; ok {
+		return b, true
+	}
+
+	// Unambiguous prefix of a canonical or Filipino name, e.g. "Phili", "Deuter"
+	if len(strings.TrimLeft(key, "123 ")) < 3 {
+		return nil, false
+	}
+	var match *Book
+	for i := range books {
+		b := &books[i]
+		if strings.HasPrefix(normalizeBook(b.Name), key) || strings.HasPrefix(normalizeBook(b.Filipino), key) {
+			if match != nil && match != b {
+				return nil, false
+			}
+			match = b
+		}
+	}
+	return match, match != nil
+}
+
+// ordinals maps ordinal prefixes to book numbers
+var ordinals = map[string]string{
+	"i": "1", "ii": "2", "iii": "3",
+	"1st": "1", "2nd": "2", "3rd": "3",
+	"first": "1", "second": "2", "third": "3",
+	"unang": "1", "ikalawang": "2", "ikatlong": "3",
+}
+
+// normalizeBook turns a book name into its lookup key: lowercase, no periods
+// or hyphens, single spaces, and the ordinal written as a digit followed by a space
+func normalizeBook(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer(".", " ", "-", " ").Replace(name)
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if n, ok := ordinals[fields[0]]; ok && len(fields) > 1 {
+		fields[0] = n
+	}
+	// "1cor" -> "1 cor"
+	if f := fields[0]; len(f) > 1 && f[0] >= '1' && f[0] <= '3' && f[1] >= 'a' && f[1] <= 'z' {
+		fields = append([]string{f[:1], f[1:]}, fields[1:]...)
+	}
+	return strings.Join(fields, " ")
+}
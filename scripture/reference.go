@@ -0,0 +1,250 @@
+package scripture
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Range is a contiguous span of verses. A zero verse means the whole chapter,
+// so "Psalm 23" is {23, 0, 23, 0} and "Genesis 1:26-2:3" is {1, 26, 2, 3}.
+type Range struct {
+	StartChapter int `json:"start_chapter"`
+	StartVerse   int `json:"start_verse,omitempty"`
+	EndChapter   int `json:"end_chapter"`
+	EndVerse     int `json:"end_verse,omitempty"`
+}
+
+// Contains reports whether the verse falls within the range
+func (r Range) Contains(chapter, verse int) bool {
+	after := chapter > r.StartChapter || (chapter == r.StartChapter && (r.StartVerse == 0 || verse >= r.StartVerse))
+	before := chapter < r.EndChapter || (chapter == r.EndChapter && (r.EndVerse == 0 || verse <= r.EndVerse))
+	return after && before
+}
+
+// Reference is a passage of one book, made of one or more ranges, e.g.
+// "Romans 8:28, 31-39" or "John 3:16; 4:1"
+type Reference struct {
+	Book   *Book
+	Ranges []Range
+}
+
+// Contains reports whether the verse falls within any of the reference's ranges
+func (r Reference) Contains(chapter, verse int) bool {
+	for _, rng := range r.Ranges {
+		if rng.Contains(chapter, verse) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// bookPartPattern splits "1 Cor 13:4-7" into the book and the chapter/verse part
+	bookPartPattern = regexp.MustCompile(`^\s*((?:[1-3]\s*)?[^\d:;,]*[^\d\s:;,])\s*(.*)$`)
+	// rangePattern matches "13", "13:4", "13:4-7", "1:26-2:3", "4-7" and "23-24"
+	rangePattern = regexp.MustCompile(`^(\d+)(?::(\d+))?(?:-(\d+)(?::(\d+))?)?$`)
+	// dashReplacer normalizes dashes and removes the spaces around them
+	dashReplacer = strings.NewReplacer("–", "-", "—", "-", " -", "-", "- ", "-")
+)
+
+// Parse parses one or more references separated by semicolons. A part
+// without a book continues the previous reference, so "John 3:16; 4:1" is a
+// single reference. Commas separate verse lists within a chapter ("Romans
+// 8:28, 31-39") or chapters in chapter-only references ("Psalm 23, 27").
+func Parse(s string) ([]Reference, error) {
+	var refs []Reference
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		numbers := part
+		if m := bookPartPattern.FindStringSubmatch(part); m != nil {
+			book, ok := LookupBook(m[1])
+			if !ok {
+				return nil, fmt.Errorf("unknown book %q", m[1])
+			}
+			refs = append(refs, Reference{Book: book})
+			numbers = m[2]
+		} else if len(refs) == 0 {
+			return nil, fmt.Errorf("reference %q has no book", s)
+		}
+
+		ref := &refs[len(refs)-1]
+		ranges, err := parseRanges(ref.Book, numbers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %v", part, err)
+		}
+		ref.Ranges = append(ref.Ranges, ranges...)
+	}
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("empty reference")
+	}
+	return refs, nil
+}
+
+// ParseOne parses a string holding exactly one reference
+func ParseOne(s string) (Reference, error) {
+	refs, err := Parse(s)
+	if err != nil {
+		return Reference{}, err
+	}
+	if len(refs) != 1 {
+		return Reference{}, fmt.Errorf("%q holds %d references", s, len(refs))
+	}
+	return refs[0], nil
+}
+
+// parseRanges parses the chapter and verse part of a reference, e.g. "8:28, 31-39"
+func parseRanges(book *Book, s string) ([]Range, error) {
+	s = strings.TrimSpace(dashReplacer.Replace(s))
+	if s == "" {
+		// A book on its own covers all of it
+		return []Range{{StartChapter: 1, EndChapter: book.Chapters}}, nil
+	}
+
+	var ranges []Range
+	chapter := 0 // chapter of the previous item when it had verses
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ReplaceAll(item, " ", "")
+		m := rangePattern.FindStringSubmatch(item)
+		if m == nil {
+			return nil, fmt.Errorf("cannot parse %q", item)
+		}
+		a, b, c, d := atoi(m[1]), atoi(m[2]), atoi(m[3]), atoi(m[4])
+
+		var r Range
+		switch {
+		case m[2] != "" && m[4] != "": // 1:26-2:3
+			r = Range{a, b, c, d}
+		case m[2] != "" && m[3] != "": // 6:16-18
+			r = Range{a, b, a, c}
+		case m[2] != "": // 3:16
+			r = Range{a, b, a, b}
+		case book.Chapters == 1 && m[3] != "": // Jude 3-5
+			r = Range{1, a, 1, c}
+		case book.Chapters == 1: // Jude 3
+			r = Range{1, a, 1, a}
+		case chapter > 0 && m[3] != "": // the "31-39" in "8:28, 31-39"
+			r = Range{chapter, a, chapter, c}
+		case chapter > 0: // the "31" in "8:28, 31"
+			r = Range{chapter, a, chapter, a}
+		case m[3] != "": // Psalm 23-24
+			r = Range{a, 0, c, 0}
+		default: // Psalm 23
+			r = Range{a, 0, a, 0}
+		}
+
+		if err := r.validate(book); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		if r.EndVerse > 0 {
+			chapter = r.EndChapter
+		}
+	}
+	return ranges, nil
+}
+
+// validate checks the range against the book's chapters
+func (r Range) validate(book *Book) error {
+	for _, ch := range []int{r.StartChapter, r.EndChapter} {
+		if ch < 1 || ch > book.Chapters {
+			return fmt.Errorf("%s has no chapter %d", book.Name, ch)
+		}
+	}
+	if r.EndChapter < r.StartChapter || (r.EndChapter == r.StartChapter && r.EndVerse < r.StartVerse) {
+		return fmt.Errorf("range ends before it starts")
+	}
+	if (r.StartVerse == 0) != (r.EndVerse == 0) {
+		return fmt.Errorf("range mixes chapters and verses")
+	}
+	return nil
+}
+
+// String formats the reference canonically, e.g. "1 Corinthians 13:4-7",
+// "Romans 8:28, 31-39", "John 3:16; 4:1" or "Psalm 23"
+func (r Reference) String() string {
+	name := r.Book.Name
+	if name == "Psalms" && len(r.Ranges) == 1 && r.Ranges[0].StartChapter == r.Ranges[0].EndChapter {
+		name = "Psalm"
+	}
+	if len(r.Ranges) == 1 && r.Ranges[0] == (Range{1, 0, r.Book.Chapters, 0}) {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name)
+	for i, rng := range r.Ranges {
+		prev := Range{}
+		if i > 0 {
+			prev = r.Ranges[i-1]
+		}
+		switch {
+		case i == 0:
+			b.WriteString(" ")
+		case rng.StartVerse > 0 && prev.EndVerse > 0 && rng.StartChapter == prev.EndChapter,
+			rng.StartVerse == 0 && prev.EndVerse == 0:
+			b.WriteString(", ")
+			if rng.StartVerse > 0 {
+				b.WriteString(formatVerses(rng))
+				continue
+			}
+		default:
+			b.WriteString("; ")
+		}
+		b.WriteString(formatRange(r.Book, rng))
+	}
+	return b.String()
+}
+
+// formatRange formats a range with its chapter, e.g. "6:16-18" or "1:26-2:3"
+func formatRange(book *Book, r Range) string {
+	switch {
+	case r.StartVerse == 0 && r.StartChapter == r.EndChapter:
+		return strconv.Itoa(r.StartChapter)
+	case r.StartVerse == 0:
+		return fmt.Sprintf("%d-%d", r.StartChapter, r.EndChapter)
+	case book.Chapters == 1:
+		return formatVerses(r)
+	case r.StartChapter != r.EndChapter:
+		return fmt.Sprintf("%d:%d-%d:%d", r.StartChapter, r.StartVerse, r.EndChapter, r.EndVerse)
+	}
+	return fmt.Sprintf("%d:%s", r.StartChapter, formatVerses(r))
+}
+
+// formatVerses formats the verses of a single-chapter range, e.g. "16-18"
+func formatVerses(r Range) string {
+	if r.StartVerse == r.EndVerse {
+		return strconv.Itoa(r.StartVerse)
+	}
+	return fmt.Sprintf("%d-%d", r.StartVerse, r.EndVerse)
+}
+
+// Format formats references canonically, separated by semicolons
+func Format(refs []Reference) string {
+	parts := make([]string, len(refs))
+	for i, r := range refs {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Normalize parses a reference string and formats it canonically, e.g.
+// "1 cor 13:4 - 7" becomes "1 Corinthians 13:4-7"
+func Normalize(s string) (string, error) {
+	refs, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Format(refs), nil
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
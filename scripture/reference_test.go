@@ -0,0 +1,129 @@
+package scripture
+
+import (
+	"testing"
+)
+
+func TestLookupBook(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Psalms", "Psalms"},
+		{"Psalm", "Psalms"},
+		{"Ps.", "Psalms"},
+		{"1 Cor", "1 Corinthians"},
+		{"1Cor", "1 Corinthians"},
+		{"I Corinthians", "1 Corinthians"},
+		{"First Corinthians", "1 Corinthians"},
+		{"Jn", "John"},
+		{"1 Jn", "1 John"},
+		{"Phil", "Philippians"},
+		{"Phlm", "Philemon"},
+		{"song of songs", "Song of Solomon"},
+		{"Deuter", "Deuteronomy"},
+		{"Mga Awit", "Psalms"},
+		{"Awit", "Psalms"},
+		{"Mga Taga-Roma", "Romans"},
+		{"Roma", "Romans"},
+		{"1 Mga Taga-Corinto", "1 Corinthians"},
+		{"1 Corinto", "1 Corinthians"},
+		{"Juan", "John"},
+		{"Unang Juan", "1 John"},
+		{"Pahayag", "Revelation"},
+	}
+
+	for _, tt := range tests {
+		book, ok := LookupBook(tt.name)
+		if !ok {
+			t.Errorf("LookupBook(%q) found nothing, want %s", tt.name, tt.want)
+			continue
+		}
+		if book.Name != tt.want {
+			t.Errorf("LookupBook(%q) = %s, want %s", tt.name, book.Name, tt.want)
+		}
+	}
+
+	for _, name := range []string{"", "Jo", "Hezekiah", "Phi"} {
+		if book, ok := LookupBook(name); ok {
+			t.Errorf("LookupBook(%q) = %s, want no match", name, book.Name)
+		}
+	}
+
+	if len(Books()) != 66 {
+		t.Errorf("Expected 66 books, got %d", len(Books()))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Matthew 6:16-18", "Matthew 6:16-18"},
+		{"Psalms 71:1-13", "Psalm 71:1-13"},
+		{"Psalm 23", "Psalm 23"},
+		{"Ps 23-24", "Psalms 23-24"},
+		{"1 cor 13:4 - 7", "1 Corinthians 13:4-7"},
+		{"Jn 3:16", "John 3:16"},
+		{"Genesis 1:26–2:3", "Genesis 1:26-2:3"},
+		{"Romans 8:28, 31-39", "Romans 8:28, 31-39"},
+		{"John 3:16; 4:1", "John 3:16; 4:1"},
+		{"Matthew 6:16-18; Lk 11:1-4", "Matthew 6:16-18; Luke 11:1-4"},
+		{"Psalm 23, 27", "Psalms 23, 27"},
+		{"Jude 3", "Jude 3"},
+		{"Jude", "Jude"},
+		{"Mga Awit 23:1-4", "Psalm 23:1-4"},
+		{"Juan 15:1-8", "John 15:1-8"},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.in)
+		if err != nil {
+			t.Errorf("Normalize(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRanges(t *testing.T) {
+	ref, err := ParseOne("Genesis 1:26-2:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Range{1, 26, 2, 3}); len(ref.Ranges) != 1 || ref.Ranges[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, ref.Ranges)
+	}
+	if !ref.Contains(1, 31) || !ref.Contains(2, 1) || ref.Contains(2, 4) || ref.Contains(1, 25) {
+		t.Errorf("Contains is wrong for %+v", ref.Ranges)
+	}
+
+	refs, err := Parse("Romans 8:28, 31-39; 1 Cor 13")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 || len(refs[0].Ranges) != 2 || refs[0].Ranges[1] != (Range{8, 31, 8, 39}) {
+		t.Errorf("Unexpected references: %+v", refs)
+	}
+	if !refs[1].Contains(13, 4) || refs[1].Contains(14, 1) {
+		t.Errorf("Expected 1 Corinthians 13 to cover the whole chapter")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"3:16",
+		"Hezekiah 1:1",
+		"Matthew 29:1",
+		"Matthew 6:18-16",
+		"Matthew 6:a",
+	} {
+		if refs, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", s, refs)
+		}
+	}
+}
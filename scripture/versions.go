@@ -0,0 +1,79 @@
+package scripture
+
+import (
+	"strings"
+)
+
+// Version is a Bible translation
+type Version struct {
+	Code      string `json:"code"`      // canonical code, e.g. "ESV"
+	Name      string `json:"name"`      // full name, e.g. "English Standard Version"
+	Language  string `json:"language"`  // e.g. "English", "Filipino"
+	Copyright string `json:"copyright"` // attribution to show with quoted text
+
+	aliases []string // other codes and names, e.g. "AMPLIFIED"
+}
+
+// versions is the catalogue of translations the devotionals quote from
+var versions = []Version{
+	{"AMP", "Amplified Bible", "English", "Copyright © 2015 by The Lockman Foundation. Used by permission.", []string{"AMPLIFIED", "AMPC"}},
+	{"ASV", "American Standard Version", "English", "Public domain.", nil},
+	{"CEV", "Contemporary English Version", "English", "Copyright © 1995 by American Bible Society. Used by permission.", []string{"CONTEMPORARY"}},
+	{"CSB", "Christian Standard Bible", "English", "Copyright © 2017 by Holman Bible Publishers. Used by permission.", nil},
+	{"ESV", "English Standard Version", "English", "The Holy Bible, English Standard Version®, copyright © 2001 by Crossway, a publishing ministry of Good News Publishers. Used by permission. All rights reserved.", nil},
+	{"GNT", "Good News Translation", "English", "Copyright © 1992 by American Bible Society. Used by permission.", []string{"GNB", "TEV"}},
+	{"HCSB", "Holman Christian Standard Bible", "English", "Copyright © 1999, 2000, 2002, 2003, 2009 by Holman Bible Publishers. Used by permission.", nil},
+	{"KJV", "King James Version", "English", "Public domain.", []string{"AV"}},
+	{"MSG", "The Message", "English", "Copyright © 1993, 2002, 2018 by Eugene H. Peterson. Used by permission of NavPress.", []string{"MESSAGE"}},
+	{"NASB", "New American Standard Bible", "English", "Copyright © 1960, 1971, 1977, 1995, 2020 by The Lockman Foundation. Used by permission.", []string{"NASB95", "NASB1995", "NASB2020"}},
+	{"NCV", "New Century Version", "English", "Copyright © 2005 by Thomas Nelson. Used by permission.", nil},
+	{"NET", "New English Translation", "English", "NET Bible® copyright © 1996-2017 by Biblical Studies Press, L.L.C. Used by permission.", nil},
+	{"NIV", "New International Version", "English", "Holy Bible, New International Version®, NIV® Copyright © 1973, 1978, 1984, 2011 by Biblica, Inc.® Used by permission.", nil},
+	{"NKJV", "New King James Version", "English", "Copyright © 1982 by Thomas Nelson. Used by permission.", nil},
+	{"NLT", "New Living Translation", "English", "Holy Bible, New Living Translation, copyright © 1996, 2004, 2015 by Tyndale House Foundation. Used by permission.", nil},
+	{"NRSV", "New Revised Standard Version", "English", "Copyright © 1989 National Council of the Churches of Christ in the United States of America. Used by permission.", []string{"NRSVUE"}},
+	{"PHILLIPS", "J.B. Phillips New Testament", "English", "Copyright © 1960, 1972 J. B. Phillips. Used by permission.", []string{"PHIL"}},
+	{"RSV", "Revised Standard Version", "English", "Copyright © 1946, 1952, 1971 National Council of the Churches of Christ in the United States of America. Used by permission.", nil},
+	{"TLB", "The Living Bible", "English", "Copyright © 1971 by Tyndale House Foundation. Used by permission.", []string{"LIVING"}},
+	{"TPT", "The Passion Translation", "English", "Copyright © 2017, 2018, 2020 by Passion & Fire Ministries, Inc. Used by permission.", []string{"PASSION"}},
+	{"VOICE", "The Voice", "English", "Copyright © 2012 Ecclesia Bible Society. Used by permission.", nil},
+	{"MBB", "Magandang Balita Biblia", "Filipino", "Copyright © 2005 by Philippine Bible Society. Used by permission.", []string{"MBBTAG"}},
+	{"ASND", "Ang Salita ng Diyos", "Filipino", "Copyright © 2009, 2011, 2014, 2015 by Biblica, Inc.® Used by permission.", nil},
+	{"ADB", "Ang Dating Biblia", "Filipino", "Public domain.", []string{"ADB1905"}},
+	{"FSV", "Filipino Standard Version", "Filipino", "Copyright © 2001 by Philippine Bible Society. Used by permission.", nil},
+}
+
+// versionIndex maps every upper-case code and alias to its version
+var versionIndex = make(map[string]*Version)
+
+func init() {
+	for i := range versions {
+		v := &versions[i]
+		for _, code := range append([]string{v.Code}, v.aliases...) {
+			versionIndex[code] = v
+		}
+	}
+}
+
+// Versions returns the catalogue of Bible versions
+func Versions() []Version {
+	return append([]Version(nil), versions...)
+}
+
+// LookupVersion finds a version by code or alias, ignoring case and periods.
+// An edition suffix after a hyphen is ignored, so "ESV-r" is the ESV.
+func LookupVersion(code string) (*Version, bool) {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), ".", ""))
+	if key == "" {
+		return nil, false
+	}
+	if v, ok := versionIndex[key]; ok {
+		return v, true
+	}
+	if base, _, ok := strings.Cut(key, "-"); ok {
+		if v, ok := versionIndex[base]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
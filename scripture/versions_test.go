@@ -0,0 +1,44 @@
+package scripture
+
+import (
+	"testing"
+)
+
+func TestLookupVersion(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"ESV", "ESV"},
+		{"esv", "ESV"},
+		{"ESV-r", "ESV"},
+		{"N.I.V.", "NIV"},
+		{"AMPLIFIED", "AMP"},
+		{"MESSAGE", "MSG"},
+		{"NASB1995", "NASB"},
+		{"MBB", "MBB"},
+	}
+
+	for _, tt := range tests {
+		v, ok := LookupVersion(tt.code)
+		if !ok {
+			t.Errorf("LookupVersion(%q) found nothing, want %s", tt.code, tt.want)
+			continue
+		}
+		if v.Code != tt.want {
+			t.Errorf("LookupVersion(%q) = %s, want %s", tt.code, v.Code, tt.want)
+		}
+	}
+
+	for _, code := range []string{"", "XYZ", "THE", "-r"} {
+		if v, ok := LookupVersion(code); ok {
+			t.Errorf("LookupVersion(%q) = %s, want no match", code, v.Code)
+		}
+	}
+
+	for _, v := range Versions() {
+		if v.Name == "" || v.Language == "" || v.Copyright == "" {
+			t.Errorf("Version %s is missing metadata: %+v", v.Code, v)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ImageStore stores images on disk addressed by the sha256 of their content
+type ImageStore struct {
+	dir string
+}
+
+// NewImageStore creates a new image store rooted at dir
+func NewImageStore(dir string) *ImageStore {
+	return &ImageStore{dir: dir}
+}
+
+// Put writes the image to the store and returns its content hash.
+// Storing the same content twice is a no-op.
+func (s *ImageStore) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	path := s.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create image directory: %v", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial image
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create image file: %v", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write image: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write image: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to store image: %v", err)
+	}
+
+	return hash, nil
+}
+
+// Open opens the image with the given hash for reading
+func (s *ImageStore) Open(hash string) (*os.File, error) {
+	if !isValidHash(hash) {
+		return nil, fmt.Errorf("invalid image hash: %q", hash)
+	}
+	return os.Open(s.path(hash))
+}
+
+// path returns the on-disk location of an image, sharded by the first two hex digits
+func (s *ImageStore) path(hash string) string {
+	return filepath.Join(s.dir, hash[:2], hash)
+}
+
+// isValidHash checks that hash is a lowercase hex sha256 digest
+func isValidHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}